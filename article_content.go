@@ -0,0 +1,579 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type ArticleBlockType int
+
+const (
+	ArticleBlockParagraph ArticleBlockType = iota // 段落
+	ArticleBlockHeading                           // 标题
+	ArticleBlockImage                             // 图片
+	ArticleBlockQuote                             // 引用
+	ArticleBlockCode                              // 代码
+	ArticleBlockList                              // 列表
+	ArticleBlockCard                              // 视频、专栏、番剧等卡片
+	ArticleBlockDivider                           // 分割线
+)
+
+// ArticleText 专栏正文中的一段文字，在富文本片段的基础上增加了样式。@用户、链接、表情等见 RichTextSegment
+type ArticleText struct {
+	RichTextSegment
+	Bold          bool   // 是否加粗
+	Italic        bool   // 是否斜体
+	Strikethrough bool   // 是否有删除线
+	Color         string // 文字颜色。新版专栏为 #RRGGBB 的格式，旧版专栏为 color-blue-01 这样的CSS类名。默认颜色为空
+}
+
+// ArticleImage 专栏正文中的图片
+type ArticleImage struct {
+	Url     string  // 图片url
+	Width   int     // 图片宽度。未知时为0
+	Height  int     // 图片高度。未知时为0
+	Size    float64 // 图片大小。单位为KB，未知时为0
+	Caption string  // 图片说明
+}
+
+// ArticleCard 专栏正文中的卡片
+type ArticleCard struct {
+	Type  string // 卡片类型。例如 video 、 article 、 ugc 、 opus 、 live 等
+	Id    string // 卡片对应的id。例如视频的avid、专栏的cvid
+	Title string // 标题。旧版专栏中没有此项
+	Cover string // 封面url。旧版专栏中没有此项
+	Desc  string // 描述。旧版专栏中没有此项
+	Url   string // 跳转url
+}
+
+// ArticleListItem 列表中的一项
+type ArticleListItem struct {
+	Level int           // 缩进级别，从1开始
+	Texts []ArticleText // 内容
+}
+
+// ArticleBlock 专栏正文中的一个块
+type ArticleBlock struct {
+	Type    ArticleBlockType  // 块类型
+	Texts   []ArticleText     // 段落、标题、引用的内容
+	Level   int               // 标题级别。1-6
+	Images  []ArticleImage    // 图片。一个图片块中可能有多张图片
+	Code    string            // 代码内容
+	Lang    string            // 代码语言。例如 go 、 python ，未知时为空
+	Ordered bool              // 列表是否为有序列表
+	Items   []ArticleListItem // 列表项
+	Card    *ArticleCard      // 卡片
+}
+
+// ArticleContent 专栏的完整内容
+type ArticleContent struct {
+	Id          int            // 专栏cvid。通过 GetOpusContent 获取时为0
+	OpusId      string         // 图文（opus）id。旧版专栏为空
+	Title       string         // 标题
+	Summary     string         // 摘要
+	BannerUrl   string         // 头图url
+	AuthorMid   int            // 作者mid
+	AuthorName  string         // 作者昵称
+	PublishTime int            // 发布时间。时间戳
+	Blocks      []ArticleBlock // 正文
+}
+
+type GetArticleContentParam struct {
+	Id int `json:"id"` // 专栏cvid
+}
+
+type opusWord struct {
+	Words string `json:"words"` // 文字
+	Color string `json:"color"` // 颜色
+	Style struct {
+		Bold          bool `json:"bold"`
+		Italic        bool `json:"italic"`
+		Strikethrough bool `json:"strikethrough"`
+	} `json:"style"` // 样式
+}
+
+type opusNode struct {
+	Type    string               `json:"type"` // TEXT_NODE_TYPE_WORD：文字。TEXT_NODE_TYPE_RICH：富文本。TEXT_NODE_TYPE_FORMULA：公式
+	Word    *opusWord            `json:"word"`
+	Rich    *DynamicRichTextNode `json:"rich"`
+	Formula *struct {
+		LatexContent string `json:"latex_content"`
+	} `json:"formula"`
+}
+
+type opusPic struct {
+	Url    string  `json:"url"`
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+	Size   float64 `json:"size"`
+}
+
+type opusParagraph struct {
+	ParaType int `json:"para_type"` // 1：文字。2：图片。3：分割线。4：引用。5：列表。6：卡片。7：代码
+	Text     *struct {
+		Nodes []opusNode `json:"nodes"`
+	} `json:"text"`
+	Heading *struct {
+		Level int        `json:"level"`
+		Nodes []opusNode `json:"nodes"`
+	} `json:"heading"`
+	Pic *struct {
+		Pics []opusPic `json:"pics"`
+	} `json:"pic"`
+	List *struct {
+		Style int `json:"style"` // 1：有序列表。2：无序列表
+		Items []struct {
+			Level int        `json:"level"`
+			Order int        `json:"order"`
+			Nodes []opusNode `json:"nodes"`
+		} `json:"items"`
+	} `json:"list"`
+	LinkCard *struct {
+		Card map[string]any `json:"card"`
+	} `json:"link_card"`
+	Code *struct {
+		Content string `json:"content"`
+		Lang    string `json:"lang"`
+	} `json:"code"`
+}
+
+type opusContent struct {
+	Paragraphs []opusParagraph `json:"paragraphs"`
+}
+
+type articleView struct {
+	Id          int    `json:"id"`
+	Title       string `json:"title"`
+	Summary     string `json:"summary"`
+	BannerUrl   string `json:"banner_url"`
+	PublishTime int    `json:"publish_time"`
+	Author      struct {
+		Mid  int    `json:"mid"`
+		Name string `json:"name"`
+	} `json:"author"`
+	Content string `json:"content"` // 旧版专栏的正文HTML
+	Opus    *struct {
+		OpusId  json.Number `json:"opus_id"`
+		Content opusContent `json:"content"`
+	} `json:"opus"` // 新版专栏的正文
+}
+
+// GetArticleContent 获取专栏的完整内容，支持旧版专栏和使用新版编辑器发布的专栏
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/article/view.md
+func (c *Client) GetArticleContent(param GetArticleContentParam) (*ArticleContent, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/article/view"
+	)
+	view, err := execute[*articleView](c, method, url, param, fillWbiHandler(c.wbi, c.GetCookies()))
+	if err != nil {
+		return nil, err
+	}
+	content := &ArticleContent{
+		Id:          view.Id,
+		Title:       view.Title,
+		Summary:     view.Summary,
+		BannerUrl:   normalizeArticleUrl(view.BannerUrl),
+		AuthorMid:   view.Author.Mid,
+		AuthorName:  view.Author.Name,
+		PublishTime: view.PublishTime,
+	}
+	if view.Opus != nil && len(view.Opus.Content.Paragraphs) > 0 {
+		content.OpusId = view.Opus.OpusId.String()
+		content.Blocks = parseOpusParagraphs(view.Opus.Content.Paragraphs)
+		return content, nil
+	}
+	if content.Blocks, err = parseArticleHTML(view.Content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+type GetOpusContentParam struct {
+	Id       string `json:"id"`                                            // 图文（opus）id
+	Features string `json:"features" request:"query,default=htmlNewStyle"` // 功能开关。htmlNewStyle
+}
+
+type opusDetail struct {
+	Item struct {
+		IdStr   string `json:"id_str"`
+		Modules []struct {
+			ModuleType  string `json:"module_type"`
+			ModuleTitle *struct {
+				Text string `json:"text"`
+			} `json:"module_title"`
+			ModuleAuthor *struct {
+				Mid   int    `json:"mid"`
+				Name  string `json:"name"`
+				PubTs int    `json:"pub_ts"`
+			} `json:"module_author"`
+			ModuleContent *opusContent `json:"module_content"`
+		} `json:"modules"`
+	} `json:"item"`
+}
+
+// GetOpusContent 获取图文（opus）的完整内容，新版专栏和图文动态都可以通过此接口获取
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/opus/detail.md
+func (c *Client) GetOpusContent(param GetOpusContentParam) (*ArticleContent, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/polymer/web-dynamic/v1/opus/detail"
+	)
+	detail, err := execute[*opusDetail](c, method, url, param, fillWbiHandler(c.wbi, c.GetCookies()))
+	if err != nil {
+		return nil, err
+	}
+	content := &ArticleContent{OpusId: detail.Item.IdStr}
+	for _, module := range detail.Item.Modules {
+		switch {
+		case module.ModuleTitle != nil:
+			content.Title = module.ModuleTitle.Text
+		case module.ModuleAuthor != nil:
+			content.AuthorMid = module.ModuleAuthor.Mid
+			content.AuthorName = module.ModuleAuthor.Name
+			content.PublishTime = module.ModuleAuthor.PubTs
+		case module.ModuleContent != nil:
+			content.Blocks = append(content.Blocks, parseOpusParagraphs(module.ModuleContent.Paragraphs)...)
+		}
+	}
+	return content, nil
+}
+
+func normalizeArticleUrl(u string) string {
+	if strings.HasPrefix(u, "//") {
+		return "https:" + u
+	}
+	return u
+}
+
+// parseOpusParagraphs 解析图文（opus）格式的正文
+func parseOpusParagraphs(paragraphs []opusParagraph) []ArticleBlock {
+	blocks := make([]ArticleBlock, 0, len(paragraphs))
+	for _, para := range paragraphs {
+		var block ArticleBlock
+		switch {
+		case para.Heading != nil:
+			block = ArticleBlock{Type: ArticleBlockHeading, Level: min(max(para.Heading.Level, 1), 6), Texts: parseOpusNodes(para.Heading.Nodes)}
+		case para.Pic != nil:
+			block = ArticleBlock{Type: ArticleBlockImage}
+			for _, pic := range para.Pic.Pics {
+				block.Images = append(block.Images, ArticleImage{
+					Url:    normalizeArticleUrl(pic.Url),
+					Width:  pic.Width,
+					Height: pic.Height,
+					Size:   pic.Size,
+				})
+			}
+		case para.List != nil:
+			block = ArticleBlock{Type: ArticleBlockList, Ordered: para.List.Style == 1}
+			for _, item := range para.List.Items {
+				block.Items = append(block.Items, ArticleListItem{Level: max(item.Level, 1), Texts: parseOpusNodes(item.Nodes)})
+			}
+		case para.LinkCard != nil:
+			block = ArticleBlock{Type: ArticleBlockCard, Card: parseOpusCard(para.LinkCard.Card)}
+		case para.Code != nil:
+			block = ArticleBlock{Type: ArticleBlockCode, Code: para.Code.Content, Lang: strings.TrimPrefix(para.Code.Lang, "language-")}
+		case para.ParaType == 3:
+			block = ArticleBlock{Type: ArticleBlockDivider}
+		case para.Text != nil:
+			block = ArticleBlock{Type: ArticleBlockParagraph, Texts: parseOpusNodes(para.Text.Nodes)}
+			if para.ParaType == 4 {
+				block.Type = ArticleBlockQuote
+			}
+		default:
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func parseOpusNodes(nodes []opusNode) []ArticleText {
+	texts := make([]ArticleText, 0, len(nodes))
+	for _, node := range nodes {
+		switch {
+		case node.Word != nil:
+			texts = append(texts, ArticleText{
+				RichTextSegment: RichTextSegment{Type: RichTextSegmentText, Text: node.Word.Words},
+				Bold:            node.Word.Style.Bold,
+				Italic:          node.Word.Style.Italic,
+				Strikethrough:   node.Word.Style.Strikethrough,
+				Color:           node.Word.Color,
+			})
+		case node.Rich != nil:
+			// 图文（opus）正文中的富文本节点与动态的格式相同
+			texts = append(texts, ArticleText{RichTextSegment: (&DynamicDesc{RichTextNodes: []DynamicRichTextNode{*node.Rich}}).RichText()[0]})
+		case node.Formula != nil:
+			texts = append(texts, ArticleText{RichTextSegment: RichTextSegment{Type: RichTextSegmentText, Text: node.Formula.LatexContent}})
+		}
+	}
+	return texts
+}
+
+// parseOpusCard 解析卡片。卡片的具体内容放在与类型同名的字段中，例如 {"type":"LINK_CARD_TYPE_UGC","oid":"1","ugc":{...}}
+func parseOpusCard(card map[string]any) *ArticleCard {
+	typ := strings.ToLower(strings.TrimPrefix(cast.ToString(card["type"]), "LINK_CARD_TYPE_"))
+	result := &ArticleCard{Type: typ, Id: cast.ToString(card["oid"])}
+	detail, ok := card[typ].(map[string]any)
+	if !ok {
+		return result
+	}
+	if id := cast.ToString(detail["id_str"]); len(id) > 0 {
+		result.Id = id
+	}
+	result.Title = cast.ToString(detail["title"])
+	result.Cover = normalizeArticleUrl(cast.ToString(detail["cover"]))
+	for _, key := range []string{"desc_second", "desc", "desc1"} {
+		if desc := cast.ToString(detail[key]); len(desc) > 0 {
+			result.Desc = desc
+			break
+		}
+	}
+	result.Url = normalizeArticleUrl(cast.ToString(detail["jump_url"]))
+	return result
+}
+
+// parseArticleHTML 解析旧版专栏的正文HTML
+func parseArticleHTML(content string) ([]ArticleBlock, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var blocks []ArticleBlock
+	for _, node := range nodes {
+		blocks = appendArticleHTMLBlocks(blocks, node)
+	}
+	return blocks, nil
+}
+
+func appendArticleHTMLBlocks(blocks []ArticleBlock, n *html.Node) []ArticleBlock {
+	if n.Type == html.TextNode {
+		if len(strings.TrimSpace(n.Data)) > 0 {
+			blocks = append(blocks, ArticleBlock{Type: ArticleBlockParagraph, Texts: []ArticleText{newArticleText(n.Data, articleStyle{})}})
+		}
+		return blocks
+	}
+	if n.Type != html.ElementNode {
+		return blocks
+	}
+	switch n.DataAtom {
+	case atom.P:
+		// 段落中同时有文字和图片时，先放文字，再依次放图片
+		if texts := parseArticleHTMLTexts(nil, n, articleStyle{}); len(texts) > 0 {
+			blocks = append(blocks, ArticleBlock{Type: ArticleBlockParagraph, Texts: texts})
+		}
+		for _, img := range findHTMLElements(nil, n, atom.Img) {
+			blocks = appendArticleHTMLFigure(blocks, img)
+		}
+		return blocks
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return append(blocks, ArticleBlock{Type: ArticleBlockHeading, Level: level, Texts: parseArticleHTMLTexts(nil, n, articleStyle{})})
+	case atom.Blockquote:
+		return append(blocks, ArticleBlock{Type: ArticleBlockQuote, Texts: parseArticleHTMLTexts(nil, n, articleStyle{})})
+	case atom.Ul, atom.Ol:
+		block := ArticleBlock{Type: ArticleBlockList, Ordered: n.DataAtom == atom.Ol}
+		for li := n.FirstChild; li != nil; li = li.NextSibling {
+			if li.DataAtom == atom.Li {
+				block.Items = append(block.Items, ArticleListItem{Level: 1, Texts: parseArticleHTMLTexts(nil, li, articleStyle{})})
+			}
+		}
+		return append(blocks, block)
+	case atom.Pre:
+		code, ok := htmlAttr(n, "codecontent")
+		if !ok {
+			code = htmlText(n)
+		}
+		lang, _ := htmlAttr(n, "data-lang")
+		return append(blocks, ArticleBlock{Type: ArticleBlockCode, Code: code, Lang: strings.TrimPrefix(lang, "language-")})
+	case atom.Hr:
+		return append(blocks, ArticleBlock{Type: ArticleBlockDivider})
+	case atom.Figure, atom.Img:
+		return appendArticleHTMLFigure(blocks, n)
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		blocks = appendArticleHTMLBlocks(blocks, child)
+	}
+	return blocks
+}
+
+// articleCardUrls 旧版专栏中卡片的跳转url前缀
+var articleCardUrls = map[string]string{
+	"video":   "https://www.bilibili.com/video/av",
+	"article": "https://www.bilibili.com/read/cv",
+	"fanju":   "https://www.bilibili.com/bangumi/media/md",
+	"music":   "https://www.bilibili.com/audio/au",
+	"live":    "https://live.bilibili.com/",
+}
+
+// appendArticleHTMLFigure 解析 <figure> 中的图片、分割线或者卡片
+func appendArticleHTMLFigure(blocks []ArticleBlock, n *html.Node) []ArticleBlock {
+	img := n
+	if n.DataAtom != atom.Img {
+		if img = findHTMLElement(n, atom.Img); img == nil {
+			return blocks
+		}
+	}
+	class, _ := htmlAttr(img, "class")
+	if strings.Contains(class, "cut-off") {
+		return append(blocks, ArticleBlock{Type: ArticleBlockDivider})
+	}
+	for _, c := range strings.Fields(class) {
+		typ, ok := strings.CutSuffix(c, "-card")
+		if !ok {
+			continue
+		}
+		ids, _ := htmlAttr(img, "aid")
+		for _, id := range strings.Split(ids, ",") {
+			if id = strings.TrimSpace(id); len(id) == 0 {
+				continue
+			}
+			card := &ArticleCard{Type: typ, Id: id}
+			if prefix, ok := articleCardUrls[typ]; ok {
+				card.Url = prefix + id
+			}
+			blocks = append(blocks, ArticleBlock{Type: ArticleBlockCard, Card: card})
+		}
+		return blocks
+	}
+	src, ok := htmlAttr(img, "data-src")
+	if !ok {
+		src, _ = htmlAttr(img, "src")
+	}
+	if len(src) == 0 {
+		return blocks
+	}
+	image := ArticleImage{Url: normalizeArticleUrl(src)}
+	if width, ok := htmlAttr(img, "width"); ok {
+		image.Width = cast.ToInt(width)
+	}
+	if height, ok := htmlAttr(img, "height"); ok {
+		image.Height = cast.ToInt(height)
+	}
+	if size, ok := htmlAttr(img, "data-size"); ok {
+		image.Size = cast.ToFloat64(size) / 1024
+	}
+	if caption := findHTMLElement(n, atom.Figcaption); caption != nil {
+		image.Caption = strings.TrimSpace(htmlText(caption))
+	}
+	return append(blocks, ArticleBlock{Type: ArticleBlockImage, Images: []ArticleImage{image}})
+}
+
+type articleStyle struct {
+	bold, italic, strikethrough bool
+	color, url                  string
+}
+
+func newArticleText(text string, style articleStyle) ArticleText {
+	segment := RichTextSegment{Type: RichTextSegmentText, Text: text}
+	if len(style.url) > 0 {
+		segment.Type = RichTextSegmentLink
+		segment.Url = style.url
+	}
+	return ArticleText{
+		RichTextSegment: segment,
+		Bold:            style.bold,
+		Italic:          style.italic,
+		Strikethrough:   style.strikethrough,
+		Color:           style.color,
+	}
+}
+
+// parseArticleHTMLTexts 解析HTML中的行内元素，块级元素之间用换行分隔
+func parseArticleHTMLTexts(texts []ArticleText, n *html.Node, style articleStyle) []ArticleText {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case html.TextNode:
+			if len(child.Data) > 0 {
+				texts = append(texts, newArticleText(child.Data, style))
+			}
+			continue
+		case html.ElementNode:
+		default:
+			continue
+		}
+		s := style
+		switch child.DataAtom {
+		case atom.Strong, atom.B:
+			s.bold = true
+		case atom.Em, atom.I:
+			s.italic = true
+		case atom.S, atom.Del, atom.Strike:
+			s.strikethrough = true
+		case atom.A:
+			href, _ := htmlAttr(child, "href")
+			s.url = normalizeArticleUrl(href)
+		case atom.Span:
+			if class, ok := htmlAttr(child, "class"); ok {
+				for _, c := range strings.Fields(class) {
+					if strings.HasPrefix(c, "color-") {
+						s.color = c
+					}
+				}
+			}
+		case atom.Br:
+			texts = append(texts, newArticleText("\n", style))
+			continue
+		case atom.P, atom.Div, atom.Li, atom.Blockquote:
+			if len(texts) > 0 && !strings.HasSuffix(texts[len(texts)-1].Text, "\n") {
+				texts = append(texts, newArticleText("\n", style))
+			}
+		}
+		texts = parseArticleHTMLTexts(texts, child, s)
+	}
+	return texts
+}
+
+func htmlAttr(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+func htmlText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+func findHTMLElement(n *html.Node, a atom.Atom) *html.Node {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == a {
+			return child
+		}
+		if found := findHTMLElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findHTMLElements(found []*html.Node, n *html.Node, a atom.Atom) []*html.Node {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == a {
+			found = append(found, child)
+		}
+		found = findHTMLElements(found, child, a)
+	}
+	return found
+}
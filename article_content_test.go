@@ -0,0 +1,97 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseArticleHTML(t *testing.T) {
+	const content = `<h1>标题</h1><p>普通<strong>加粗</strong><a href="//www.bilibili.com/read/cv1">链接</a></p>` +
+		`<figure class="img-box"><img data-src="//i0.hdslb.com/a.png" width="640" height="480"><figcaption class="caption">说明</figcaption></figure>` +
+		`<figure class="img-box"><img src="//i0.hdslb.com/cut.png" class="cut-off-5"></figure>` +
+		`<figure class="img-box"><img src="//i0.hdslb.com/card.png" class="video-card nomal" aid="1,2"></figure>` +
+		`<blockquote><p>第一行</p><p>第二行</p></blockquote><ul><li>一</li><li>二</li></ul>` +
+		`<pre class="code-box" data-lang="language-go" codecontent="fmt.Println(1)"><code>fmt.Println(1)</code></pre>`
+	blocks, err := parseArticleHTML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	article := &ArticleContent{Blocks: blocks}
+	article.RewriteImages(func(url string) string { return "local/" + url[len(url)-5:] })
+	expected := "# 标题\n\n普通**加粗**[链接](https://www.bilibili.com/read/cv1)\n\n![说明](local/a.png)\n\n---\n\n" +
+		"[https://www.bilibili.com/video/av1](https://www.bilibili.com/video/av1)\n\n" +
+		"[https://www.bilibili.com/video/av2](https://www.bilibili.com/video/av2)\n\n" +
+		"> 第一行  \n> 第二行\n\n- 一\n- 二\n\n```go\nfmt.Println(1)\n```\n"
+	if actual := article.Markdown(); actual != expected {
+		t.Fatalf("unexpected markdown:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestParseOpusParagraphs(t *testing.T) {
+	const content = `[
+		{"para_type":1,"text":{"nodes":[{"type":"TEXT_NODE_TYPE_WORD","word":{"words":"你好","color":"#ff0000","style":{"bold":true}}},
+			{"type":"TEXT_NODE_TYPE_RICH","rich":{"type":"RICH_TEXT_NODE_TYPE_AT","text":"@张三","rid":"123"}}]}},
+		{"para_type":2,"pic":{"pics":[{"url":"https://i0.hdslb.com/a.png","width":100,"height":50,"size":12.5}]}},
+		{"para_type":5,"list":{"style":1,"items":[{"level":1,"order":1,"nodes":[{"type":"TEXT_NODE_TYPE_WORD","word":{"words":"一"}}]}]}},
+		{"para_type":6,"link_card":{"card":{"type":"LINK_CARD_TYPE_UGC","oid":"1","ugc":{"id_str":"BV1xx","title":"视频","jump_url":"//www.bilibili.com/video/BV1xx"}}}}
+	]`
+	var paragraphs []opusParagraph
+	if err := json.Unmarshal([]byte(content), &paragraphs); err != nil {
+		t.Fatal(err)
+	}
+	article := &ArticleContent{Title: "测试", Blocks: parseOpusParagraphs(paragraphs)}
+	expected := "<h1>测试</h1>\n" +
+		`<p><strong><span style="color:#ff0000">你好</span></strong><a href="https://space.bilibili.com/123">@张三</a></p>` + "\n" +
+		`<figure><img src="https://i0.hdslb.com/a.png" width="100" height="50" alt=""></figure>` + "\n" +
+		"<ol>\n<li>一</li>\n</ol>\n" +
+		`<p><a href="https://www.bilibili.com/video/BV1xx">视频</a></p>` + "\n"
+	if actual := article.HTML(); actual != expected {
+		t.Fatalf("unexpected html:\n%s\nexpected:\n%s", actual, expected)
+	}
+	if card := article.Blocks[3].Card; card.Type != "ugc" || card.Id != "BV1xx" {
+		t.Fatalf("unexpected card: %+v", card)
+	}
+}
+
+func TestParseArticleHTMLParagraphImage(t *testing.T) {
+	const content = `<p>前面的文字<img src="//i0.hdslb.com/a.png">后面的文字<img src="//i0.hdslb.com/b.png"></p>`
+	blocks, err := parseArticleHTML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	article := &ArticleContent{Blocks: blocks}
+	expected := "前面的文字后面的文字\n\n![](https://i0.hdslb.com/a.png)\n\n![](https://i0.hdslb.com/b.png)\n"
+	if actual := article.Markdown(); actual != expected {
+		t.Fatalf("unexpected markdown:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestArticleHTMLUnsafeUrl(t *testing.T) {
+	const content = `<p><a href="javascript:alert(1)">脚本</a><a href=" JavaScript:alert(1)">空格</a><a href="/read/cv1">相对</a></p>` +
+		`<figure class="img-box"><img src="data:image/png;base64,AAAA"></figure>`
+	blocks, err := parseArticleHTML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks = append(blocks, ArticleBlock{Type: ArticleBlockCard, Card: &ArticleCard{Title: "卡片", Url: "vbscript:msgbox(1)"}})
+	article := &ArticleContent{Blocks: blocks}
+	expected := `<p>脚本空格<a href="/read/cv1">相对</a></p>` + "\n<p>卡片</p>\n"
+	if actual := article.HTML(); actual != expected {
+		t.Fatalf("unexpected html:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestArticleMarkdownUnsafeUrl(t *testing.T) {
+	const content = `<p><a href="javascript:alert(1)">脚本</a><a href="/read/cv1 (2)">相对</a></p>` +
+		`<figure class="img-box"><img src="data:image/png;base64,AAAA"></figure>`
+	blocks, err := parseArticleHTML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks = append(blocks, ArticleBlock{Type: ArticleBlockCard, Card: &ArticleCard{Title: "卡片", Url: "vbscript:msgbox(1)"}})
+	article := &ArticleContent{Blocks: blocks}
+	expected := "脚本[相对](/read/cv1%20%282%29)\n\n卡片\n"
+	if actual := article.Markdown(); actual != expected {
+		t.Fatalf("unexpected markdown:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
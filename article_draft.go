@@ -0,0 +1,136 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+type ArticleCategory struct {
+	Category
+	Children []Category `json:"children"` // 子分类。投稿时需要使用子分类的id
+}
+
+// GetArticleCategories 获取专栏的分类列表
+func (c *Client) GetArticleCategories() ([]ArticleCategory, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/article/categories"
+	)
+	return execute[[]ArticleCategory](c, method, url, nil)
+}
+
+// UploadArticleImage 上传专栏中使用的图片，也可以用作头图。返回图片url和图片大小（单位为字节）
+func (c *Client) UploadArticleImage(fileName string, file io.Reader) (url string, size int, err error) {
+	biliJct := c.getCookie("bili_jct")
+	if len(biliJct) == 0 {
+		return "", 0, errors.New("B站登录过期")
+	}
+	resp, err := c.resty.R().
+		SetFileReader("binary", fileName, file).SetFormData(map[string]string{
+		"csrf": biliJct,
+	}).Post("https://api.bilibili.com/x/article/creative/article/upcover")
+	if err != nil {
+		return "", 0, errors.WithStack(err)
+	}
+	if resp.StatusCode() != 200 {
+		return "", 0, errors.Errorf("status code: %d", resp.StatusCode())
+	}
+	var response commonResp
+	if err = json.Unmarshal(resp.Body(), &response); err != nil {
+		return "", 0, errors.WithStack(err)
+	}
+	if response.Code != 0 {
+		return "", 0, errors.Errorf("错误码: %d, 错误信息: %s", response.Code, response.Message)
+	}
+	var data struct {
+		Url  string `json:"url"`
+		Size int    `json:"size"`
+	}
+	if err = json.Unmarshal(response.Data, &data); err != nil {
+		return "", 0, errors.WithStack(err)
+	}
+	return data.Url, data.Size, errors.WithStack(err)
+}
+
+type ArticleDraftParam struct {
+	Aid             int      `json:"aid,omitempty" request:"query,omitempty"`               // 草稿id。保存草稿时为0则新建草稿；发布时为0则直接发布
+	Title           string   `json:"title"`                                                 // 标题
+	BannerUrl       string   `json:"banner_url,omitempty" request:"query,omitempty"`        // 头图url。可以通过 UploadArticleImage 上传
+	Content         string   `json:"content"`                                               // 正文HTML。可以通过 ConvertMarkdownToArticle 生成
+	Summary         string   `json:"summary,omitempty" request:"query,omitempty"`           // 摘要。默认为正文的开头
+	Words           int      `json:"words"`                                                 // 正文字数
+	Category        int      `json:"category"`                                              // 分类id。需要使用 GetArticleCategories 中的子分类
+	ListId          int      `json:"list_id,omitempty" request:"query,omitempty"`           // 文集id。0为不加入文集
+	Tid             int      `json:"tid" request:"query,default=4"`                         // 4
+	Reprint         int      `json:"reprint"`                                               // 转载声明。同 Article.Reprint
+	Original        int      `json:"original"`                                              // 是否为原创。0：非原创。1：原创
+	Tags            []string `json:"tags,omitempty" request:"query,omitempty"`              // 标签
+	ImageUrls       []string `json:"image_urls,omitempty" request:"query,omitempty"`        // 封面图url。默认使用头图
+	OriginImageUrls []string `json:"origin_image_urls,omitempty" request:"query,omitempty"` // 封面原图url
+	DynamicIntro    string   `json:"dynamic_intro,omitempty" request:"query,omitempty"`     // 粉丝动态文案
+	MediaId         int      `json:"media_id,omitempty" request:"query,omitempty"`          // 关联的番剧mdid
+	Spoiler         int      `json:"spoiler,omitempty" request:"query,omitempty"`           // 是否含有剧透。0：否。1：是
+}
+
+type ArticleDraftResult struct {
+	Aid int `json:"aid"` // 草稿id，发布时为专栏cvid
+}
+
+// SaveArticleDraft 新建或者更新专栏草稿
+func (c *Client) SaveArticleDraft(param ArticleDraftParam) (*ArticleDraftResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/article/creative/draft/addupdate"
+	)
+	return execute[*ArticleDraftResult](c, method, url, param, fillCsrf(c))
+}
+
+// PublishArticle 发布专栏。Aid 不为0时会发布该草稿，发布后需要等待审核
+func (c *Client) PublishArticle(param ArticleDraftParam) (*ArticleDraftResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/article/creative/article/submit"
+	)
+	return execute[*ArticleDraftResult](c, method, url, param, fillCsrf(c))
+}
+
+type DeleteArticleDraftParam struct {
+	Aid int `json:"aid"` // 草稿id
+}
+
+// DeleteArticleDraft 删除专栏草稿
+func (c *Client) DeleteArticleDraft(param DeleteArticleDraftParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/article/creative/draft/delete"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type GetArticleDraftsParam struct {
+	Pn int `json:"pn,omitempty" request:"query,omitempty"` // 页码。默认为1
+}
+
+type ArticleDraftPage struct {
+	Pn    int `json:"pn"`    // 当前页码
+	Ps    int `json:"ps"`    // 每页项数
+	Total int `json:"total"` // 草稿总数
+}
+
+type ArticleDrafts struct {
+	Drafts []Article        `json:"drafts"` // 草稿列表，其中 Id 为草稿id
+	Page   ArticleDraftPage `json:"page"`   // 分页信息
+}
+
+// GetArticleDrafts 获取自己的专栏草稿列表
+func (c *Client) GetArticleDrafts(param GetArticleDraftsParam) (*ArticleDrafts, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/article/creative/draft/list"
+	)
+	return execute[*ArticleDrafts](c, method, url, param)
+}
@@ -0,0 +1,334 @@
+package bilibili
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ArticleMarkdown Markdown转换为专栏正文的结果
+type ArticleMarkdown struct {
+	Content   string   // 正文HTML，即 ArticleDraftParam.Content
+	Words     int      // 正文字数，即 ArticleDraftParam.Words
+	ImageUrls []string // 正文中的图片url，按出现顺序排列
+}
+
+var (
+	regMarkdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	regMarkdownDivider = regexp.MustCompile(`^(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	regMarkdownImage   = regexp.MustCompile(`^!\[([^\]]*)\]\(\s*(\S+?)(?:\s+"[^"]*")?\s*\)$`)
+	regMarkdownList    = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])\s+(.*)$`)
+)
+
+// ConvertMarkdownToArticle 将Markdown转换为专栏正文HTML，支持标题、段落、加粗、斜体、删除线、行内代码、代码块、
+// 引用、列表、图片、链接和分割线。resolveImage 用于把Markdown中的图片地址转换为B站的图片url，
+// 例如读取本地图片后通过 UploadArticleImage 上传，为 nil 时保持原样
+func ConvertMarkdownToArticle(markdown string, resolveImage func(src string) (string, error)) (*ArticleMarkdown, error) {
+	m := &markdownConverter{resolveImage: resolveImage}
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); {
+		n, err := m.block(lines[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+	}
+	result := &ArticleMarkdown{Content: m.sb.String(), ImageUrls: m.images}
+	blocks, err := parseArticleHTML(result.Content)
+	if err != nil {
+		return nil, err
+	}
+	result.Words = countArticleWords(blocks)
+	return result, nil
+}
+
+// countArticleWords 统计正文中除空白字符以外的字数，不包括图片说明和列表的序号
+func countArticleWords(blocks []ArticleBlock) int {
+	var words int
+	count := func(s string) {
+		for _, r := range s {
+			if !unicode.IsSpace(r) {
+				words++
+			}
+		}
+	}
+	for _, block := range blocks {
+		for _, text := range block.Texts {
+			count(text.Text)
+		}
+		for _, item := range block.Items {
+			for _, text := range item.Texts {
+				count(text.Text)
+			}
+		}
+		count(block.Code)
+		if block.Card != nil {
+			count(block.Card.Title)
+		}
+	}
+	return words
+}
+
+type markdownConverter struct {
+	resolveImage func(src string) (string, error)
+	sb           strings.Builder
+	images       []string
+}
+
+// block 转换从 lines[0] 开始的一个块，返回消耗的行数
+func (m *markdownConverter) block(lines []string) (int, error) {
+	trimmed := strings.TrimSpace(lines[0])
+	switch {
+	case len(trimmed) == 0:
+		return 1, nil
+	case strings.HasPrefix(trimmed, "```"):
+		return m.code(lines), nil
+	case regMarkdownDivider.MatchString(trimmed):
+		m.sb.WriteString("<hr>")
+		return 1, nil
+	}
+	if matches := regMarkdownHeading.FindStringSubmatch(trimmed); matches != nil {
+		tag := "h" + strconv.Itoa(len(matches[1]))
+		content, err := m.inline(matches[2])
+		if err != nil {
+			return 0, err
+		}
+		m.sb.WriteString("<" + tag + ">" + content + "</" + tag + ">")
+		return 1, nil
+	}
+	if matches := regMarkdownImage.FindStringSubmatch(trimmed); matches != nil {
+		src, err := m.image(matches[2])
+		if err != nil {
+			return 0, err
+		}
+		m.sb.WriteString(`<figure class="img-box" contenteditable="false"><img src="` + html.EscapeString(src) + `">`)
+		m.sb.WriteString(`<figcaption class="caption" contenteditable="false">` + html.EscapeString(matches[1]) + `</figcaption></figure>`)
+		return 1, nil
+	}
+	if strings.HasPrefix(trimmed, ">") {
+		return m.quote(lines)
+	}
+	if regMarkdownList.MatchString(trimmed) {
+		return m.list(lines)
+	}
+	return m.paragraph(lines)
+}
+
+func isMarkdownBlockStart(trimmed string) bool {
+	return len(trimmed) == 0 || strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, ">") ||
+		regMarkdownDivider.MatchString(trimmed) || regMarkdownHeading.MatchString(trimmed) ||
+		regMarkdownImage.MatchString(trimmed) || regMarkdownList.MatchString(trimmed)
+}
+
+func (m *markdownConverter) code(lines []string) int {
+	lang := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[0]), "```"))
+	n := 1
+	var code []string
+	for ; n < len(lines); n++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[n]), "```") {
+			n++
+			break
+		}
+		code = append(code, lines[n])
+	}
+	m.sb.WriteString("<pre")
+	if len(lang) > 0 {
+		m.sb.WriteString(` data-lang="` + html.EscapeString(lang) + `"`)
+	}
+	m.sb.WriteString("><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+	return n
+}
+
+func (m *markdownConverter) quote(lines []string) (int, error) {
+	n := 0
+	var paragraphs [][]string
+	current := []string{}
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		line := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+		if len(line) == 0 {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, current)
+				current = []string{}
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	m.sb.WriteString("<blockquote>")
+	for _, paragraph := range paragraphs {
+		content, err := m.inline(strings.Join(paragraph, " "))
+		if err != nil {
+			return 0, err
+		}
+		m.sb.WriteString("<p>" + content + "</p>")
+	}
+	m.sb.WriteString("</blockquote>")
+	return n, nil
+}
+
+func (m *markdownConverter) list(lines []string) (int, error) {
+	first := regMarkdownList.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	ordered := first[1][0] >= '0' && first[1][0] <= '9'
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	m.sb.WriteString("<" + tag + ">")
+	n := 0
+	for ; n < len(lines); n++ {
+		matches := regMarkdownList.FindStringSubmatch(strings.TrimSpace(lines[n]))
+		if matches == nil || (matches[1][0] >= '0' && matches[1][0] <= '9') != ordered {
+			break
+		}
+		content, err := m.inline(matches[2])
+		if err != nil {
+			return 0, err
+		}
+		m.sb.WriteString("<li>" + content + "</li>")
+	}
+	m.sb.WriteString("</" + tag + ">")
+	return n, nil
+}
+
+func (m *markdownConverter) paragraph(lines []string) (int, error) {
+	n := 1
+	for n < len(lines) && !isMarkdownBlockStart(strings.TrimSpace(lines[n])) {
+		n++
+	}
+	parts := make([]string, 0, n)
+	for i, line := range lines[:n] {
+		content, err := m.inline(strings.TrimSpace(line))
+		if err != nil {
+			return 0, err
+		}
+		// 行尾的两个空格表示硬换行
+		if i < n-1 && strings.HasSuffix(line, "  ") {
+			content += "<br>"
+		}
+		parts = append(parts, content)
+	}
+	m.sb.WriteString("<p>" + strings.Join(parts, " ") + "</p>")
+	return n, nil
+}
+
+func (m *markdownConverter) image(src string) (string, error) {
+	if m.resolveImage != nil {
+		var err error
+		if src, err = m.resolveImage(src); err != nil {
+			return "", err
+		}
+	}
+	m.images = append(m.images, src)
+	return src, nil
+}
+
+const markdownPunctuation = "\\`*_{}[]()#+-.!~|<>\""
+
+// inline 转换行内元素
+func (m *markdownConverter) inline(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && strings.IndexByte(markdownPunctuation, s[i+1]) >= 0:
+			sb.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+		case s[i] == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				sb.WriteString("<code>" + html.EscapeString(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(s[i:], "!["):
+			if alt, src, n, ok := parseMarkdownLink(s[i+1:]); ok {
+				src, err := m.image(src)
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(alt) + `">`)
+				i += n + 1
+				continue
+			}
+		case s[i] == '[':
+			if text, href, n, ok := parseMarkdownLink(s[i:]); ok {
+				content, err := m.inline(text)
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString(`<a href="` + html.EscapeString(href) + `">` + content + "</a>")
+				i += n
+				continue
+			}
+		case strings.HasPrefix(s[i:], "**"), strings.HasPrefix(s[i:], "__"), strings.HasPrefix(s[i:], "~~"):
+			delim := s[i : i+2]
+			if end := strings.Index(s[i+2:], delim); end > 0 {
+				content, err := m.inline(s[i+2 : i+2+end])
+				if err != nil {
+					return "", err
+				}
+				if delim == "~~" {
+					sb.WriteString("<s>" + content + "</s>")
+				} else {
+					sb.WriteString("<strong>" + content + "</strong>")
+				}
+				i += end + 4
+				continue
+			}
+		case s[i] == '*':
+			if end := strings.IndexByte(s[i+1:], '*'); end > 0 {
+				content, err := m.inline(s[i+1 : i+1+end])
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString("<em>" + content + "</em>")
+				i += end + 2
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		sb.WriteString(html.EscapeString(s[i : i+size]))
+		i += size
+	}
+	return sb.String(), nil
+}
+
+// parseMarkdownLink 解析以 [ 开头的 [text](url "title") ，返回文字、url和消耗的字节数
+func parseMarkdownLink(s string) (text, url string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(s) || s[i+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			fields := strings.Fields(s[i+2 : i+2+end])
+			if len(fields) == 0 {
+				return "", "", 0, false
+			}
+			return s[1:i], strings.Trim(fields[0], "<>"), i + 3 + end, true
+		}
+	}
+	return "", "", 0, false
+}
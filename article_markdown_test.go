@@ -0,0 +1,38 @@
+package bilibili
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertMarkdownToArticle(t *testing.T) {
+	const markdown = "# 标题\n\n第一行**加粗**和[链接](https://www.bilibili.com)\n第二行\n\n" +
+		"> 引用\n\n- 一\n- 二\n\n1. 甲\n2. 乙\n\n![说明](a.png)\n\n---\n\n```go\nfmt.Println(\"<>\")\n```\n"
+	result, err := ConvertMarkdownToArticle(markdown, func(src string) (string, error) {
+		return "https://i0.hdslb.com/bfs/article/" + src, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join([]string{
+		"<h1>标题</h1>",
+		`<p>第一行<strong>加粗</strong>和<a href="https://www.bilibili.com">链接</a> 第二行</p>`,
+		"<blockquote><p>引用</p></blockquote>",
+		"<ul><li>一</li><li>二</li></ul>",
+		"<ol><li>甲</li><li>乙</li></ol>",
+		`<figure class="img-box" contenteditable="false"><img src="https://i0.hdslb.com/bfs/article/a.png">` +
+			`<figcaption class="caption" contenteditable="false">说明</figcaption></figure>`,
+		"<hr>",
+		`<pre data-lang="go"><code>fmt.Println(&#34;&lt;&gt;&#34;)</code></pre>`,
+	}, "")
+	if result.Content != expected {
+		t.Fatalf("unexpected content:\n%s\nexpected:\n%s", result.Content, expected)
+	}
+	if len(result.ImageUrls) != 1 || result.ImageUrls[0] != "https://i0.hdslb.com/bfs/article/a.png" {
+		t.Fatalf("unexpected image urls: %v", result.ImageUrls)
+	}
+	// 标题2 + 第一行加粗和链接第二行11 + 引用2 + 一二2 + 甲乙2 + fmt.Println("<>")17
+	if result.Words != 36 {
+		t.Fatalf("unexpected words: %d", result.Words)
+	}
+}
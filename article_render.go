@@ -0,0 +1,262 @@
+package bilibili
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// RewriteImages 使用 rewrite 替换正文中所有图片的url，包括头图、卡片封面和表情，可以用于把图片镜像到本地
+func (a *ArticleContent) RewriteImages(rewrite func(url string) string) {
+	if len(a.BannerUrl) > 0 {
+		a.BannerUrl = rewrite(a.BannerUrl)
+	}
+	rewriteTexts := func(texts []ArticleText) {
+		for i := range texts {
+			if texts[i].Type == RichTextSegmentEmote && len(texts[i].Url) > 0 {
+				texts[i].Url = rewrite(texts[i].Url)
+			}
+		}
+	}
+	for i := range a.Blocks {
+		block := &a.Blocks[i]
+		rewriteTexts(block.Texts)
+		for j := range block.Items {
+			rewriteTexts(block.Items[j].Texts)
+		}
+		for j := range block.Images {
+			block.Images[j].Url = rewrite(block.Images[j].Url)
+		}
+		if block.Card != nil && len(block.Card.Cover) > 0 {
+			block.Card.Cover = rewrite(block.Card.Cover)
+		}
+	}
+}
+
+// PlainText 渲染为纯文本，块之间用空行分隔，图片只保留说明
+func (a *ArticleContent) PlainText() string {
+	parts := make([]string, 0, len(a.Blocks))
+	plain := func(texts []ArticleText) string {
+		var sb strings.Builder
+		for _, text := range texts {
+			sb.WriteString(text.Text)
+		}
+		return sb.String()
+	}
+	for _, block := range a.Blocks {
+		var part string
+		switch block.Type {
+		case ArticleBlockParagraph, ArticleBlockHeading, ArticleBlockQuote:
+			part = plain(block.Texts)
+		case ArticleBlockImage:
+			captions := make([]string, 0, len(block.Images))
+			for _, image := range block.Images {
+				if len(image.Caption) > 0 {
+					captions = append(captions, image.Caption)
+				}
+			}
+			part = strings.Join(captions, "\n")
+		case ArticleBlockCode:
+			part = block.Code
+		case ArticleBlockList:
+			items := make([]string, 0, len(block.Items))
+			for i, item := range block.Items {
+				items = append(items, articleListMarker(block.Ordered, i+1)+plain(item.Texts))
+			}
+			part = strings.Join(items, "\n")
+		case ArticleBlockCard:
+			part = block.Card.Title
+			if len(part) == 0 {
+				part = block.Card.Url
+			}
+		}
+		if len(strings.TrimSpace(part)) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// markdown 渲染为Markdown，样式使用 ** 、 * 、 ~~ 表示，颜色会被忽略
+func (t *ArticleText) markdown() string {
+	s := RichText{t.RichTextSegment}.Markdown()
+	if len(strings.TrimSpace(s)) == 0 {
+		return s
+	}
+	if t.Strikethrough {
+		s = "~~" + s + "~~"
+	}
+	if t.Italic {
+		s = "*" + s + "*"
+	}
+	if t.Bold {
+		s = "**" + s + "**"
+	}
+	return s
+}
+
+func articleTextsMarkdown(texts []ArticleText) string {
+	var sb strings.Builder
+	for i := range texts {
+		sb.WriteString(texts[i].markdown())
+	}
+	return sb.String()
+}
+
+// articleListMarker 返回列表项的标记，index 从1开始
+func articleListMarker(ordered bool, index int) string {
+	if ordered {
+		return strconv.Itoa(index) + ". "
+	}
+	return "- "
+}
+
+// Markdown 渲染为Markdown，标题会作为一级标题放在最前面。
+// 与 HTML 相同，链接和图片只保留 http 、 https 以及没有协议的地址
+func (a *ArticleContent) Markdown() string {
+	parts := make([]string, 0, len(a.Blocks)+1)
+	if len(a.Title) > 0 {
+		parts = append(parts, "# "+markdownEscaper.Replace(a.Title))
+	}
+	for _, block := range a.Blocks {
+		var part string
+		switch block.Type {
+		case ArticleBlockParagraph:
+			part = articleTextsMarkdown(block.Texts)
+		case ArticleBlockHeading:
+			part = strings.Repeat("#", min(max(block.Level, 1), 6)) + " " + articleTextsMarkdown(block.Texts)
+		case ArticleBlockImage:
+			images := make([]string, 0, len(block.Images))
+			for _, image := range block.Images {
+				if u := markdownUrl(image.Url); len(u) > 0 {
+					images = append(images, "!["+markdownEscaper.Replace(image.Caption)+"]("+u+")")
+				}
+			}
+			part = strings.Join(images, "\n\n")
+		case ArticleBlockQuote:
+			lines := strings.Split(articleTextsMarkdown(block.Texts), "\n")
+			part = "> " + strings.Join(lines, "\n> ")
+		case ArticleBlockCode:
+			part = "```" + block.Lang + "\n" + strings.TrimSuffix(block.Code, "\n") + "\n```"
+		case ArticleBlockList:
+			items := make([]string, 0, len(block.Items))
+			for i, item := range block.Items {
+				indent := strings.Repeat("  ", max(item.Level, 1)-1)
+				items = append(items, indent+articleListMarker(block.Ordered, i+1)+articleTextsMarkdown(item.Texts))
+			}
+			part = strings.Join(items, "\n")
+		case ArticleBlockCard:
+			title := block.Card.Title
+			if len(title) == 0 {
+				title = block.Card.Url
+			}
+			if u := markdownUrl(block.Card.Url); len(u) > 0 {
+				part = "[" + markdownEscaper.Replace(title) + "](" + u + ")"
+			} else {
+				part = markdownEscaper.Replace(title)
+			}
+		case ArticleBlockDivider:
+			part = "---"
+		}
+		if len(strings.TrimSpace(part)) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// html 渲染为HTML片段。颜色为 #RRGGBB 时使用 style 属性，否则作为 class
+func (t *ArticleText) html() string {
+	s := RichText{t.RichTextSegment}.HTML()
+	if len(t.Color) > 0 {
+		color := html.EscapeString(t.Color)
+		if strings.HasPrefix(t.Color, "#") {
+			s = `<span style="color:` + color + `">` + s + `</span>`
+		} else {
+			s = `<span class="` + color + `">` + s + `</span>`
+		}
+	}
+	if t.Strikethrough {
+		s = "<s>" + s + "</s>"
+	}
+	if t.Italic {
+		s = "<em>" + s + "</em>"
+	}
+	if t.Bold {
+		s = "<strong>" + s + "</strong>"
+	}
+	return s
+}
+
+func articleTextsHTML(texts []ArticleText) string {
+	var sb strings.Builder
+	for i := range texts {
+		sb.WriteString(texts[i].html())
+	}
+	return sb.String()
+}
+
+// HTML 渲染为不含B站样式的简洁HTML片段，标题会作为 <h1> 放在最前面。
+// 链接和图片只保留 http 、 https 以及没有协议的地址
+func (a *ArticleContent) HTML() string {
+	var sb strings.Builder
+	if len(a.Title) > 0 {
+		sb.WriteString("<h1>" + html.EscapeString(a.Title) + "</h1>\n")
+	}
+	for _, block := range a.Blocks {
+		switch block.Type {
+		case ArticleBlockParagraph:
+			sb.WriteString("<p>" + articleTextsHTML(block.Texts) + "</p>\n")
+		case ArticleBlockHeading:
+			tag := "h" + strconv.Itoa(min(max(block.Level, 1), 6))
+			sb.WriteString("<" + tag + ">" + articleTextsHTML(block.Texts) + "</" + tag + ">\n")
+		case ArticleBlockImage:
+			for _, image := range block.Images {
+				src := safeUrl(image.Url)
+				if len(src) == 0 {
+					continue
+				}
+				sb.WriteString(`<figure><img src="` + html.EscapeString(src) + `"`)
+				if image.Width > 0 && image.Height > 0 {
+					sb.WriteString(` width="` + strconv.Itoa(image.Width) + `" height="` + strconv.Itoa(image.Height) + `"`)
+				}
+				sb.WriteString(` alt="` + html.EscapeString(image.Caption) + `">`)
+				if len(image.Caption) > 0 {
+					sb.WriteString("<figcaption>" + html.EscapeString(image.Caption) + "</figcaption>")
+				}
+				sb.WriteString("</figure>\n")
+			}
+		case ArticleBlockQuote:
+			sb.WriteString("<blockquote><p>" + articleTextsHTML(block.Texts) + "</p></blockquote>\n")
+		case ArticleBlockCode:
+			sb.WriteString("<pre><code")
+			if len(block.Lang) > 0 {
+				sb.WriteString(` class="language-` + html.EscapeString(block.Lang) + `"`)
+			}
+			sb.WriteString(">" + html.EscapeString(block.Code) + "</code></pre>\n")
+		case ArticleBlockList:
+			tag := "ul"
+			if block.Ordered {
+				tag = "ol"
+			}
+			sb.WriteString("<" + tag + ">\n")
+			for _, item := range block.Items {
+				sb.WriteString("<li>" + articleTextsHTML(item.Texts) + "</li>\n")
+			}
+			sb.WriteString("</" + tag + ">\n")
+		case ArticleBlockCard:
+			title := block.Card.Title
+			if len(title) == 0 {
+				title = block.Card.Url
+			}
+			if u := safeUrl(block.Card.Url); len(u) > 0 {
+				sb.WriteString(`<p><a href="` + html.EscapeString(u) + `">` + html.EscapeString(title) + "</a></p>\n")
+			} else {
+				sb.WriteString("<p>" + html.EscapeString(title) + "</p>\n")
+			}
+		case ArticleBlockDivider:
+			sb.WriteString("<hr>\n")
+		}
+	}
+	return sb.String()
+}
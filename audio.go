@@ -0,0 +1,231 @@
+package bilibili
+
+import (
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// 音频音质，见 GetAudioStreamParam.Quality
+const (
+	AudioQuality128K = 0 // 128K
+	AudioQuality192K = 1 // 192K
+	AudioQuality320K = 2 // 320K
+	AudioQualityFlac = 3 // 无损 FLAC
+)
+
+// ParseAudioId 解析音频的au号，例如“au123456”或“AU123456”，也可以直接传入数字。解析失败时返回0
+func ParseAudioId(s string) int {
+	s = strings.TrimSpace(s)
+	if len(s) > 2 && strings.EqualFold(s[:2], "au") {
+		s = s[2:]
+	}
+	return cast.ToInt(s)
+}
+
+type AudioParam struct {
+	Sid int `json:"sid"` // 音频auid
+}
+
+type AudioStatistic struct {
+	Sid     int `json:"sid"`     // 音频auid或歌单amid
+	Play    int `json:"play"`    // 播放数
+	Collect int `json:"collect"` // 收藏数
+	Comment int `json:"comment"` // 评论数
+	Share   int `json:"share"`   // 分享数
+}
+
+type AudioInfo struct {
+	Id         int            `json:"id"`         // 音频auid
+	Uid        int            `json:"uid"`        // UP主mid
+	Uname      string         `json:"uname"`      // UP主昵称
+	Author     string         `json:"author"`     // 作者名
+	Title      string         `json:"title"`      // 歌曲标题
+	Cover      string         `json:"cover"`      // 封面url
+	Intro      string         `json:"intro"`      // 歌曲简介
+	Lyric      string         `json:"lyric"`      // 歌词文件url。没有歌词时为空
+	Crtype     int            `json:"crtype"`     // 1
+	Duration   int            `json:"duration"`   // 歌曲时长。单位为秒
+	Passtime   int            `json:"passtime"`   // 发布时间。时间戳
+	Curtime    int            `json:"curtime"`    // 当前请求时间。时间戳
+	Aid        int            `json:"aid"`        // 关联稿件avid。没有关联稿件时为0
+	Bvid       string         `json:"bvid"`       // 关联稿件bvid
+	Cid        int            `json:"cid"`        // 关联视频cid
+	Msid       int            `json:"msid"`       // 作用尚不明确
+	Attr       int            `json:"attr"`       // 作用尚不明确
+	Limit      int            `json:"limit"`      // 作用尚不明确
+	ActivityId int            `json:"activityId"` // 活动id
+	Limitdesc  string         `json:"limitdesc"`  // 作用尚不明确
+	CoinNum    int            `json:"coin_num"`   // 投币数
+	Ctime      int            `json:"ctime"`      // 创建时间。毫秒级时间戳
+	Statistic  AudioStatistic `json:"statistic"`  // 统计信息
+	CollectIds []int          `json:"collectIds"` // 收藏了该音频的收藏夹id。需要登录
+}
+
+// GetAudioInfo 获取音频信息
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/info.md
+func (c *Client) GetAudioInfo(param AudioParam) (*AudioInfo, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://www.bilibili.com/audio/music-service-c/web/song/info"
+	)
+	return execute[*AudioInfo](c, method, url, param)
+}
+
+type GetAudioStreamParam struct {
+	Songid    int    `json:"songid"`                                  // 音频auid
+	Quality   int    `json:"quality"`                                 // 音质。见 AudioQuality128K 等常量，高音质需要大会员
+	Privilege int    `json:"privilege" request:"query,default=2"`     // 2
+	Mid       int    `json:"mid,omitempty" request:"query,omitempty"` // 当前用户mid
+	Platform  string `json:"platform" request:"query,default=web"`    // 平台
+}
+
+type AudioQuality struct {
+	Type        int    `json:"type"`        // 音质代码。见 AudioQuality128K 等常量
+	Desc        string `json:"desc"`        // 音质名称
+	Size        int    `json:"size"`        // 该音质的文件大小。单位为字节
+	Bps         string `json:"bps"`         // 比特率
+	Tag         string `json:"tag"`         // 音质标签
+	Require     int    `json:"require"`     // 是否需要大会员。0：否。1：是
+	Requiredesc string `json:"requiredesc"` // 需要的权限描述
+}
+
+type AudioStream struct {
+	Sid       int            `json:"sid"`       // 音频auid
+	Type      int            `json:"type"`      // 实际获取到的音质。-1为试听片段
+	Info      string         `json:"info"`      // 作用尚不明确
+	Timeout   int            `json:"timeout"`   // 链接有效时间。单位为秒
+	Size      int            `json:"size"`      // 文件大小。单位为字节
+	Cdns      []string       `json:"cdns"`      // 音频流url，第一个为主链接，其余为备用链接。需要带上 Referer 才能访问
+	Qualities []AudioQuality `json:"qualities"` // 可选的音质列表
+	Title     string         `json:"title"`     // 歌曲标题
+	Cover     string         `json:"cover"`     // 封面url
+}
+
+// GetAudioStream 获取音频流url
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/musicstream_url.md
+func (c *Client) GetAudioStream(param GetAudioStreamParam) (*AudioStream, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/audio/music-service-c/url"
+	)
+	if param.Mid == 0 {
+		param.Mid = cast.ToInt(c.getCookie("DedeUserID"))
+	}
+	return execute[*AudioStream](c, method, url, param)
+}
+
+// GetAudioLyric 获取音频的LRC格式歌词，没有歌词时返回空字符串
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/info.md
+func (c *Client) GetAudioLyric(param AudioParam) (string, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://www.bilibili.com/audio/music-service-c/web/song/lyric"
+	)
+	return execute[string](c, method, url, param)
+}
+
+type AudioPage[T any] struct {
+	CurPage   int `json:"curPage"`   // 当前页码
+	PageCount int `json:"pageCount"` // 总页数
+	TotalSize int `json:"totalSize"` // 总数
+	PageSize  int `json:"pageSize"`  // 每页项数
+	Data      []T `json:"data"`      // 列表
+}
+
+type GetUserAudiosParam struct {
+	Uid   int `json:"uid"`                                       // UP主mid
+	Pn    int `json:"pn,omitempty" request:"query,omitempty"`    // 页码。默认为1
+	Ps    int `json:"ps,omitempty" request:"query,omitempty"`    // 每页项数。默认为30
+	Order int `json:"order,omitempty" request:"query,omitempty"` // 排序方式。1：最新发布。2：最多播放。3：最多收藏
+}
+
+// GetUserAudios 获取UP主投稿的音频列表
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/list.md
+func (c *Client) GetUserAudios(param GetUserAudiosParam) (*AudioPage[AudioInfo], error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://www.bilibili.com/audio/music-service-c/web/song/upper"
+	)
+	return execute[*AudioPage[AudioInfo]](c, method, url, param)
+}
+
+type AudioMenu struct {
+	MenuId       int            `json:"menuId"`       // 歌单amid
+	Uid          int            `json:"uid"`          // 创建者mid
+	Uname        string         `json:"uname"`        // 创建者昵称
+	Title        string         `json:"title"`        // 歌单标题
+	Cover        string         `json:"cover"`        // 封面url
+	Intro        string         `json:"intro"`        // 歌单简介
+	Type         int            `json:"type"`         // 歌单类型。1：普通歌单。2：专辑
+	Off          int            `json:"off"`          // 歌单是否公开。0：公开。1：私密
+	Ctime        int            `json:"ctime"`        // 创建时间。时间戳
+	Curtime      int            `json:"curtime"`      // 当前请求时间。时间戳
+	Statistic    AudioStatistic `json:"statistic"`    // 统计信息
+	Snum         int            `json:"snum"`         // 歌曲数
+	Attr         int            `json:"attr"`         // 作用尚不明确
+	IsDefault    int            `json:"isDefault"`    // 是否为默认歌单。0：否。1：是
+	CollectionId int            `json:"collectionId"` // 对应的收藏夹id
+}
+
+// GetAudioMenuInfo 获取歌单信息，Sid 为歌单amid
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/album.md
+func (c *Client) GetAudioMenuInfo(param AudioParam) (*AudioMenu, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://www.bilibili.com/audio/music-service-c/web/menu/info"
+	)
+	return execute[*AudioMenu](c, method, url, param)
+}
+
+type GetAudioMenuSongsParam struct {
+	Sid int `json:"sid"`                                    // 歌单amid
+	Pn  int `json:"pn,omitempty" request:"query,omitempty"` // 页码。默认为1
+	Ps  int `json:"ps,omitempty" request:"query,omitempty"` // 每页项数。默认为100
+}
+
+// GetAudioMenuSongs 获取歌单中的歌曲列表
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/album.md
+func (c *Client) GetAudioMenuSongs(param GetAudioMenuSongsParam) (*AudioPage[AudioInfo], error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://www.bilibili.com/audio/music-service-c/web/song/of-menu"
+	)
+	return execute[*AudioPage[AudioInfo]](c, method, url, param)
+}
+
+type GetAudioMenusParam struct {
+	Pn int `json:"pn,omitempty" request:"query,omitempty"` // 页码。默认为1
+	Ps int `json:"ps,omitempty" request:"query,omitempty"` // 每页项数。默认为6
+}
+
+// GetHotAudioMenus 获取热门歌单列表
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/audio/album.md
+func (c *Client) GetHotAudioMenus(param GetAudioMenusParam) (*AudioPage[AudioMenu], error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://www.bilibili.com/audio/music-service-c/web/menu/hit"
+	)
+	return execute[*AudioPage[AudioMenu]](c, method, url, param)
+}
+
+// GetAudioStreamUrl 通过au号获取音频的主链接，quality 为期望的音质，没有权限时B站会自动降级
+func (c *Client) GetAudioStreamUrl(auid, quality int) (string, error) {
+	stream, err := c.GetAudioStream(GetAudioStreamParam{Songid: auid, Quality: quality})
+	if err != nil {
+		return "", err
+	}
+	if len(stream.Cdns) == 0 {
+		return "", errors.Errorf("音频没有可用的链接: au%d", auid)
+	}
+	return stream.Cdns[0], nil
+}
@@ -0,0 +1,16 @@
+package bilibili
+
+import "testing"
+
+func TestParseAudioId(t *testing.T) {
+	for s, expected := range map[string]int{
+		"au123456": 123456,
+		"AU123456": 123456,
+		" 123456 ": 123456,
+		"av123456": 0,
+	} {
+		if actual := ParseAudioId(s); actual != expected {
+			t.Fatalf("ParseAudioId(%q) = %d, expected %d", s, actual, expected)
+		}
+	}
+}
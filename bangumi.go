@@ -0,0 +1,409 @@
+package bilibili
+
+import (
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+type BangumiArea struct {
+	Id   int    `json:"id"`   // 地区id
+	Name string `json:"name"` // 地区名
+}
+
+type BangumiRating struct {
+	Count int     `json:"count"` // 评分人数
+	Score float64 `json:"score"` // 评分
+}
+
+type BangumiStat struct {
+	Coins     int `json:"coins"`     // 投币数
+	Danmakus  int `json:"danmakus"`  // 弹幕数
+	Favorite  int `json:"favorite"`  // 收藏数
+	Favorites int `json:"favorites"` // 追番数
+	Likes     int `json:"likes"`     // 点赞数
+	Reply     int `json:"reply"`     // 评论数
+	Share     int `json:"share"`     // 分享数
+	Views     int `json:"views"`     // 播放数
+}
+
+type BangumiSkipRange struct {
+	Start int `json:"start"` // 开始时间。单位为秒
+	End   int `json:"end"`   // 结束时间。单位为秒
+}
+
+type BangumiSkip struct {
+	Op BangumiSkipRange `json:"op"` // 片头
+	Ed BangumiSkipRange `json:"ed"` // 片尾
+}
+
+type BangumiEpisode struct {
+	Aid         int         `json:"aid"`          // 稿件avid
+	Badge       string      `json:"badge"`        // 标签。例如“会员”、“限免”
+	BadgeType   int         `json:"badge_type"`   // 标签类型
+	Bvid        string      `json:"bvid"`         // 稿件bvid
+	Cid         int         `json:"cid"`          // 视频cid
+	Cover       string      `json:"cover"`        // 封面url
+	Dimension   Dimension   `json:"dimension"`    // 分辨率
+	Duration    int         `json:"duration"`     // 时长。单位为毫秒
+	EpId        int         `json:"ep_id"`        // 剧集epid
+	Id          int         `json:"id"`           // 剧集epid
+	Link        string      `json:"link"`         // 剧集url
+	LongTitle   string      `json:"long_title"`   // 剧集标题
+	PubTime     int         `json:"pub_time"`     // 发布时间。时间戳
+	ReleaseDate string      `json:"release_date"` // 发布日期
+	ShareUrl    string      `json:"share_url"`    // 分享url
+	ShortLink   string      `json:"short_link"`   // 短链接
+	Status      int         `json:"status"`       // 剧集状态。2：免费。13：会员
+	Subtitle    string      `json:"subtitle"`     // 副标题。例如观看次数
+	Title       string      `json:"title"`        // 剧集序号。例如“1”、“SP”
+	Skip        BangumiSkip `json:"skip"`         // 跳过片头片尾的时间
+}
+
+type BangumiSeasonSection struct {
+	Id       int              `json:"id"`       // 板块id
+	Title    string           `json:"title"`    // 板块标题。例如“PV&其他”
+	Type     int              `json:"type"`     // 板块类型
+	Episodes []BangumiEpisode `json:"episodes"` // 板块中的剧集列表
+}
+
+type BangumiRelatedSeason struct {
+	MediaId     int         `json:"media_id"`     // 剧集mdid
+	SeasonId    int         `json:"season_id"`    // 剧集ssid
+	SeasonTitle string      `json:"season_title"` // 剧集标题。例如“第二季”
+	Cover       string      `json:"cover"`        // 封面url
+	Badge       string      `json:"badge"`        // 标签
+	Stat        BangumiStat `json:"stat"`         // 统计信息
+}
+
+type BangumiPublish struct {
+	IsFinish      int    `json:"is_finish"`       // 是否完结。0：连载中。1：已完结
+	IsStarted     int    `json:"is_started"`      // 是否已开播。0：未开播。1：已开播
+	PubTime       string `json:"pub_time"`        // 开播时间。格式为 YYYY-MM-DD HH:MM:SS
+	PubTimeShow   string `json:"pub_time_show"`   // 开播时间的文字描述
+	UnknowPubDate int    `json:"unknow_pub_date"` // 开播时间是否未知
+	Weekday       int    `json:"weekday"`         // 更新日。0为周日
+}
+
+type BangumiUserProgress struct {
+	LastEpId    int    `json:"last_ep_id"`    // 上次观看的剧集epid
+	LastEpIndex string `json:"last_ep_index"` // 上次观看的剧集序号
+	LastTime    int    `json:"last_time"`     // 上次观看的进度。单位为秒
+}
+
+type BangumiUserStatus struct {
+	AreaLimit    int                 `json:"area_limit"`    // 是否有地区限制
+	Follow       int                 `json:"follow"`        // 是否已追番。0：否。1：是
+	FollowStatus int                 `json:"follow_status"` // 追番状态。1：想看。2：在看。3：看过
+	Login        int                 `json:"login"`         // 是否已登录
+	Pay          int                 `json:"pay"`           // 是否已付费
+	Progress     BangumiUserProgress `json:"progress"`      // 观看进度
+	Sponsor      int                 `json:"sponsor"`       // 是否承包
+}
+
+type BangumiUpInfo struct {
+	Avatar string `json:"avatar"` // 头像url
+	Mid    int    `json:"mid"`    // 官方账号mid
+	Uname  string `json:"uname"`  // 官方账号昵称
+}
+
+type BangumiSeason struct {
+	Alias       string                 `json:"alias"`        // 别名
+	Areas       []BangumiArea          `json:"areas"`        // 地区
+	BkgCover    string                 `json:"bkg_cover"`    // 背景图url
+	Cover       string                 `json:"cover"`        // 封面url
+	Evaluate    string                 `json:"evaluate"`     // 简介
+	Episodes    []BangumiEpisode       `json:"episodes"`     // 正片剧集列表
+	JpTitle     string                 `json:"jp_title"`     // 日文标题
+	Link        string                 `json:"link"`         // 剧集url
+	MediaId     int                    `json:"media_id"`     // 剧集mdid
+	Mode        int                    `json:"mode"`         // 作用尚不明确
+	Publish     BangumiPublish         `json:"publish"`      // 开播信息
+	Rating      BangumiRating          `json:"rating"`       // 评分
+	SeasonId    int                    `json:"season_id"`    // 剧集ssid
+	SeasonTitle string                 `json:"season_title"` // 季度标题
+	Seasons     []BangumiRelatedSeason `json:"seasons"`      // 同系列的所有季度
+	Section     []BangumiSeasonSection `json:"section"`      // 花絮、PV等其它板块
+	ShareUrl    string                 `json:"share_url"`    // 分享url
+	SquareCover string                 `json:"square_cover"` // 方形封面url
+	Stat        BangumiStat            `json:"stat"`         // 统计信息
+	Status      int                    `json:"status"`       // 作用尚不明确
+	Subtitle    string                 `json:"subtitle"`     // 副标题
+	Title       string                 `json:"title"`        // 标题
+	Total       int                    `json:"total"`        // 总集数。未完结时为-1
+	Type        int                    `json:"type"`         // 剧集类型。1：番剧。2：电影。3：纪录片。4：国创。5：电视剧。7：综艺
+	UpInfo      BangumiUpInfo          `json:"up_info"`      // 官方账号信息
+	UserStatus  BangumiUserStatus      `json:"user_status"`  // 当前用户的状态。需要登录
+}
+
+// Episode 在正片和其它板块中查找剧集，找不到时返回 nil
+func (s *BangumiSeason) Episode(epId int) *BangumiEpisode {
+	find := func(episodes []BangumiEpisode) *BangumiEpisode {
+		for i := range episodes {
+			if episodes[i].EpId == epId || episodes[i].Id == epId {
+				return &episodes[i]
+			}
+		}
+		return nil
+	}
+	if ep := find(s.Episodes); ep != nil {
+		return ep
+	}
+	for _, section := range s.Section {
+		if ep := find(section.Episodes); ep != nil {
+			return ep
+		}
+	}
+	return nil
+}
+
+type GetBangumiSeasonParam struct {
+	SeasonId int `json:"season_id,omitempty" request:"query,omitempty"` // 剧集ssid。ssid 与 epid 任选一个
+	EpId     int `json:"ep_id,omitempty" request:"query,omitempty"`     // 剧集epid。ssid 与 epid 任选一个
+}
+
+// GetBangumiSeason 获取剧集明细（web端），通过epid获取时返回的是该剧集所属的季度
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/info.md
+func (c *Client) GetBangumiSeason(param GetBangumiSeasonParam) (*BangumiSeason, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/pgc/view/web/season"
+	)
+	return execute[*BangumiSeason](c, method, url, param)
+}
+
+// GetBangumiEpisode 获取剧集所属的季度，以及该剧集在季度中的信息，可以用于解析 HistoryDetail.Epid 等
+func (c *Client) GetBangumiEpisode(epId int) (*BangumiSeason, *BangumiEpisode, error) {
+	season, err := c.GetBangumiSeason(GetBangumiSeasonParam{EpId: epId})
+	if err != nil {
+		return nil, nil, err
+	}
+	episode := season.Episode(epId)
+	if episode == nil {
+		return nil, nil, errors.Errorf("剧集不存在: ep%d", epId)
+	}
+	return season, episode, nil
+}
+
+type BangumiMediaNewEp struct {
+	Id        int    `json:"id"`         // 最新一话的epid
+	Index     string `json:"index"`      // 最新一话的序号
+	IndexShow string `json:"index_show"` // 更新进度的文字描述
+}
+
+type BangumiMedia struct {
+	Areas             []BangumiArea     `json:"areas"`              // 地区
+	Cover             string            `json:"cover"`              // 封面url
+	HorizontalPicture string            `json:"horizontal_picture"` // 横版封面url
+	MediaId           int               `json:"media_id"`           // 剧集mdid
+	NewEp             BangumiMediaNewEp `json:"new_ep"`             // 最新一话
+	Rating            BangumiRating     `json:"rating"`             // 评分
+	SeasonId          int               `json:"season_id"`          // 剧集ssid
+	ShareUrl          string            `json:"share_url"`          // 分享url
+	Title             string            `json:"title"`              // 标题
+	Type              int               `json:"type"`               // 剧集类型。1：番剧。2：电影。3：纪录片。4：国创。5：电视剧。7：综艺
+	TypeName          string            `json:"type_name"`          // 剧集类型名
+}
+
+type GetBangumiMediaParam struct {
+	MediaId int `json:"media_id"` // 剧集mdid
+}
+
+// GetBangumiMedia 通过mdid获取剧集基本信息，可以用 BangumiMedia.SeasonId 继续调用 GetBangumiSeason
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/info.md
+func (c *Client) GetBangumiMedia(param GetBangumiMediaParam) (*BangumiMedia, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/pgc/review/user"
+	)
+	result, err := execute[*struct {
+		Media *BangumiMedia `json:"media"`
+	}](c, method, url, param)
+	if err != nil {
+		return nil, err
+	}
+	return result.Media, nil
+}
+
+type GetBangumiFollowListParam struct {
+	Vmid         int `json:"vmid"`                                              // 目标用户mid
+	Type         int `json:"type" request:"query,default=1"`                    // 类型。1：追番。2：追剧
+	FollowStatus int `json:"follow_status,omitempty" request:"query,omitempty"` // 追番状态筛选。0：全部。1：想看。2：在看。3：看过
+	Pn           int `json:"pn,omitempty" request:"query,omitempty"`            // 页码。默认为1
+	Ps           int `json:"ps,omitempty" request:"query,omitempty"`            // 每页项数。默认为15，最大为30
+}
+
+type BangumiFollowNewEp struct {
+	Id        int    `json:"id"`         // 最新一话的epid
+	IndexShow string `json:"index_show"` // 更新进度的文字描述
+	Cover     string `json:"cover"`      // 封面url
+	Title     string `json:"title"`      // 剧集序号
+	LongTitle string `json:"long_title"` // 剧集标题
+	PubTime   string `json:"pub_time"`   // 发布时间。格式为 YYYY-MM-DD HH:MM:SS
+	Duration  int    `json:"duration"`   // 时长。单位为毫秒
+}
+
+type BangumiFollowItem struct {
+	SeasonId       int                `json:"season_id"`        // 剧集ssid
+	MediaId        int                `json:"media_id"`         // 剧集mdid
+	SeasonType     int                `json:"season_type"`      // 剧集类型。1：番剧。2：电影。3：纪录片。4：国创。5：电视剧。7：综艺
+	SeasonTypeName string             `json:"season_type_name"` // 剧集类型名
+	Title          string             `json:"title"`            // 标题
+	Cover          string             `json:"cover"`            // 封面url
+	TotalCount     int                `json:"total_count"`      // 总集数。未完结时为-1
+	IsFinish       int                `json:"is_finish"`        // 是否完结。0：连载中。1：已完结
+	IsStarted      int                `json:"is_started"`       // 是否已开播。0：未开播。1：已开播
+	Badge          string             `json:"badge"`            // 标签。例如“会员专享”
+	NewEp          BangumiFollowNewEp `json:"new_ep"`           // 最新一话
+	Rating         BangumiRating      `json:"rating"`           // 评分
+	SquareCover    string             `json:"square_cover"`     // 方形封面url
+	Evaluate       string             `json:"evaluate"`         // 简介
+	Areas          []BangumiArea      `json:"areas"`            // 地区
+	Subtitle       string             `json:"subtitle"`         // 副标题
+	Url            string             `json:"url"`              // 剧集url
+	FollowStatus   int                `json:"follow_status"`    // 追番状态。1：想看。2：在看。3：看过
+	IsNew          int                `json:"is_new"`           // 是否有更新
+	Progress       string             `json:"progress"`         // 观看进度的文字描述。例如“看到第3话 12:34”
+	BothFollow     bool               `json:"both_follow"`      // 作用尚不明确
+}
+
+type BangumiFollowList struct {
+	List  []BangumiFollowItem `json:"list"`  // 追番列表
+	Pn    int                 `json:"pn"`    // 当前页码
+	Ps    int                 `json:"ps"`    // 每页项数
+	Total int                 `json:"total"` // 总数
+}
+
+// GetBangumiFollowList 获取用户的追番或追剧列表，查询别人时需要对方公开了追番列表
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/user/space.md
+func (c *Client) GetBangumiFollowList(param GetBangumiFollowListParam) (*BangumiFollowList, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/space/bangumi/follow/list"
+	)
+	return execute[*BangumiFollowList](c, method, url, param)
+}
+
+type BangumiFollowParam struct {
+	SeasonId int `json:"season_id"` // 剧集ssid
+}
+
+type BangumiFollowResult struct {
+	Fmid     int    `json:"fmid"`     // 作用尚不明确
+	Relation bool   `json:"relation"` // 作用尚不明确
+	Status   int    `json:"status"`   // 作用尚不明确
+	Toast    string `json:"toast"`    // 提示信息。例如“自己追的番就要好好看完哟~”
+}
+
+// FollowBangumi 追番或追剧
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/follow.md
+func (c *Client) FollowBangumi(param BangumiFollowParam) (*BangumiFollowResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/pgc/web/follow/add"
+	)
+	return execute[*BangumiFollowResult](c, method, url, param, fillCsrf(c))
+}
+
+// UnfollowBangumi 取消追番或追剧
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/follow.md
+func (c *Client) UnfollowBangumi(param BangumiFollowParam) (*BangumiFollowResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/pgc/web/follow/del"
+	)
+	return execute[*BangumiFollowResult](c, method, url, param, fillCsrf(c))
+}
+
+type UpdateBangumiFollowStatusParam struct {
+	SeasonId int `json:"season_id"` // 剧集ssid
+	Status   int `json:"status"`    // 追番状态。1：想看。2：在看。3：看过
+}
+
+// UpdateBangumiFollowStatus 修改追番状态
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/follow.md
+func (c *Client) UpdateBangumiFollowStatus(param UpdateBangumiFollowStatusParam) (*BangumiFollowResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/pgc/web/follow/status/update"
+	)
+	return execute[*BangumiFollowResult](c, method, url, param, fillCsrf(c))
+}
+
+type GetBangumiTimelineParam struct {
+	Types  int `json:"types" request:"query,default=1"`            // 剧集类型。1：番剧。3：纪录片。4：国创
+	Before int `json:"before,omitempty" request:"query,omitempty"` // 今天之前的天数。0-7
+	After  int `json:"after,omitempty" request:"query,omitempty"`  // 今天之后的天数。0-7
+}
+
+type BangumiTimelineEpisode struct {
+	Cover       string `json:"cover"`        // 剧集封面url
+	Delay       int    `json:"delay"`        // 是否延期。0：正常。1：延期
+	DelayId     int    `json:"delay_id"`     // 延期的剧集epid
+	DelayIndex  string `json:"delay_index"`  // 延期的剧集序号
+	DelayReason string `json:"delay_reason"` // 延期原因
+	EpCover     string `json:"ep_cover"`     // 单集封面url
+	EpisodeId   int    `json:"episode_id"`   // 剧集epid
+	Follows     string `json:"follows"`      // 追番数的文字描述
+	Plays       string `json:"plays"`        // 播放数的文字描述
+	PubIndex    string `json:"pub_index"`    // 剧集序号。例如“第3话”
+	PubTime     string `json:"pub_time"`     // 更新时间。格式为 HH:MM
+	PubTs       int    `json:"pub_ts"`       // 更新时间。时间戳
+	Published   int    `json:"published"`    // 是否已更新。0：未更新。1：已更新
+	SeasonId    int    `json:"season_id"`    // 剧集ssid
+	SquareCover string `json:"square_cover"` // 方形封面url
+	Title       string `json:"title"`        // 标题
+}
+
+type BangumiTimelineDay struct {
+	Date      string                   `json:"date"`        // 日期。格式为 M-D
+	DateTs    int                      `json:"date_ts"`     // 日期。时间戳
+	DayOfWeek int                      `json:"day_of_week"` // 星期。1-7
+	IsToday   int                      `json:"is_today"`    // 是否为今天。0：否。1：是
+	Episodes  []BangumiTimelineEpisode `json:"episodes"`    // 当天更新的剧集
+}
+
+// GetBangumiTimeline 获取新番时间表
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/timeline.md
+func (c *Client) GetBangumiTimeline(param GetBangumiTimelineParam) ([]BangumiTimelineDay, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/pgc/web/timeline"
+	)
+	return execute[[]BangumiTimelineDay](c, method, url, param)
+}
+
+type GetBangumiStreamParam struct {
+	EpId  int `json:"ep_id,omitempty" request:"query,omitempty"` // 剧集epid。epid 与 cid 任选一个
+	Cid   int `json:"cid,omitempty" request:"query,omitempty"`   // 视频cid。epid 与 cid 任选一个
+	Qn    int `json:"qn,omitempty" request:"query,omitempty"`    // 视频清晰度选择。同 GetVideoStreamParam.Qn
+	Fnval int `json:"fnval,omitempty" request:"query,omitempty"` // 视频流格式标识。同 GetVideoStreamParam.Fnval
+	Fnver int `json:"fnver,omitempty" request:"query,omitempty"` // 0
+	Fourk int `json:"fourk,omitempty" request:"query,omitempty"` // 是否允许 4K 视频。画质最高 1080P：0（默认）。画质最高 4K：1
+}
+
+type GetBangumiStreamResult struct {
+	GetVideoStreamResult
+	IsPreview int  `json:"is_preview"` // 是否为试看。0：否。1：是
+	HasPaid   bool `json:"has_paid"`   // 是否已付费
+	VipType   int  `json:"vip_type"`   // 当前用户的大会员类型
+	VipStatus int  `json:"vip_status"` // 当前用户的大会员状态
+	Status    int  `json:"status"`     // 剧集状态。2：免费。13：会员
+}
+
+// GetBangumiStream 获取剧集视频流地址_web端，返回的格式与 GetVideoStream 相同
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/bangumi/videostream_url.md
+func (c *Client) GetBangumiStream(param GetBangumiStreamParam) (*GetBangumiStreamResult, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/pgc/player/web/playurl"
+	)
+	return execute[*GetBangumiStreamResult](c, method, url, param)
+}
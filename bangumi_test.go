@@ -0,0 +1,45 @@
+package bilibili
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestBangumi(t *testing.T) {
+	c := newStubClient(t, map[string]stubHandler{
+		"/pgc/view/web/season": func(params url.Values) (any, error) {
+			if len(params.Get("ep_id")) == 0 || len(params.Get("season_id")) > 0 {
+				t.Errorf("unexpected params: %v", params)
+			}
+			// PGC相关的接口把数据放在 result 中
+			return stubResult{map[string]any{
+				"season_id": 33,
+				"title":     "番剧",
+				"episodes":  []map[string]any{{"ep_id": 101, "title": "1"}},
+				"section":   []map[string]any{{"title": "PV&其他", "episodes": []map[string]any{{"ep_id": 102, "title": "PV"}}}},
+			}}, nil
+		},
+		"/pgc/web/follow/add": func(params url.Values) (any, error) {
+			if params.Get("season_id") != "33" || params.Get("csrf") != "csrf" {
+				t.Errorf("unexpected params: %v", params)
+			}
+			return stubResult{map[string]any{"relation": true, "status": 2, "toast": "自己追的番就要好好看完哟~"}}, nil
+		},
+	})
+
+	season, episode, err := c.GetBangumiEpisode(102)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if season.SeasonId != 33 || season.Title != "番剧" || len(season.Episodes) != 1 || episode.Title != "PV" {
+		t.Fatalf("unexpected season: %+v, %+v", season, episode)
+	}
+	if _, _, err = c.GetBangumiEpisode(103); err == nil {
+		t.Fatal("expected error for unknown episode")
+	}
+
+	result, err := c.FollowBangumi(BangumiFollowParam{SeasonId: 33})
+	if err != nil || !result.Relation || result.Status != 2 || result.Toast != "自己追的番就要好好看完哟~" {
+		t.Fatalf("unexpected follow result: %+v, %v", result, err)
+	}
+}
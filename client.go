@@ -125,7 +125,7 @@ func (c *Client) GetCookies() []*http.Cookie {
 }
 
 // 根据key获取指定的cookie值
-func (c *Client) getCookie(name string) string { //nolint:unparam
+func (c *Client) getCookie(name string) string {
 	now := time.Now()
 	// 查找指定name的cookie
 	for _, cookie := range c.resty.Cookies {
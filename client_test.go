@@ -1,11 +1,75 @@
 package bilibili
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
 )
 
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// stubHandler 根据请求参数（包括 query 和表单）返回响应中的 data ，返回 Error 时响应对应的错误码
+type stubHandler func(params url.Values) (any, error)
+
+// stubResult 作为 stubHandler 的返回值时，数据会放在响应的 result 中，模拟PGC相关的接口
+type stubResult struct {
+	result any
+}
+
+// newStubClient 返回一个已登录（mid为1）且不会发出真实请求的 Client ，按请求路径调用 handlers 中对应的函数生成响应
+func newStubClient(t *testing.T, handlers map[string]stubHandler) *Client {
+	restyClient := resty.New().SetTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := map[string]any{"code": -404, "message": "啥都木有"}
+		if r.Body == nil {
+			// 参数全部放在 query 中的 POST 请求没有请求体
+			r.Body = http.NoBody
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if handler, ok := handlers[r.URL.Path]; !ok {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		} else if data, err := handler(r.Form); err == nil {
+			if result, ok := data.(stubResult); ok {
+				body = map[string]any{"code": 0, "message": "success", "result": result.result}
+			} else {
+				body = map[string]any{"code": 0, "message": "0", "data": data}
+			}
+		} else if e := (Error{}); errors.As(err, &e) {
+			body = map[string]any{"code": e.Code, "message": e.Message}
+		} else {
+			t.Error(err)
+		}
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(buf)),
+			Request:    r,
+		}, nil
+	}))
+	c := NewWithClient(restyClient)
+	c.SetRawCookies("DedeUserID=1; bili_jct=csrf")
+	// 预置 WBI 的密钥，避免请求 nav 接口
+	c.wbi = NewDefaultWbi().WithStorage(&MemoryStorage{data: make(map[string]any)})
+	c.wbi.SetKeys(strings.Repeat("0123456789abcdef", 2), strings.Repeat("fedcba9876543210", 2))
+	return c
+}
+
 func deepEquals(a, b []*http.Cookie) bool {
 	if len(a) != len(b) {
 		return false
@@ -205,3 +205,55 @@ func (c *Client) GetCommentsHotReply(param GetCommentsHotReplyParam) (*CommentsH
 	)
 	return execute[*CommentsHotReply](c, method, url, param)
 }
+
+type GetCommentsMainParam struct {
+	Type          int    `json:"type"`                                               // 评论区类型代码，见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/comment/readme.md
+	Oid           int    `json:"oid"`                                                // 目标评论区 id
+	Mode          int    `json:"mode,omitempty" request:"query,omitempty"`           // 排序方式。默认为3。0 3：仅按热度。1：按热度+按时间。2：仅按时间
+	PaginationStr string `json:"pagination_str,omitempty" request:"query,omitempty"` // 分页信息。格式为 {"offset":"xxx"}，xxx 为上一页返回的 next_offset，首页留空
+	Plat          int    `json:"plat,omitempty" request:"query,omitempty"`           // 平台类型。默认为1
+	SeekRpid      int    `json:"seek_rpid,omitempty" request:"query,omitempty"`      // 定位到的评论 rpid
+	WebLocation   int    `json:"web_location,omitempty" request:"query,omitempty"`   // 1315875
+}
+
+type CommentsPaginationReply struct {
+	NextOffset string `json:"next_offset"` // 下一页的偏移量。为空表示没有下一页
+	PrevOffset string `json:"prev_offset"` // 上一页的偏移量
+}
+
+type CommentsCursor struct {
+	IsBegin         bool                    `json:"is_begin"`         // 是否为第一页
+	Prev            int                     `json:"prev"`             // 上页页码
+	Next            int                     `json:"next"`             // 下页页码
+	IsEnd           bool                    `json:"is_end"`           // 是否为最后一页
+	Mode            int                     `json:"mode"`             // 排序方式
+	ModeText        string                  `json:"mode_text"`        // 排序方式文案
+	AllCount        int                     `json:"all_count"`        // 全部评论条数
+	SessionId       string                  `json:"session_id"`       // (?)
+	Name            string                  `json:"name"`             // 评论区标题。如：热门评论
+	PaginationReply CommentsPaginationReply `json:"pagination_reply"` // 分页信息
+}
+
+type CommentsMainUpper struct {
+	Mid int      `json:"mid"` // UP 主 mid
+	Top *Comment `json:"top"` // 置顶评论。无则为 null
+}
+
+type CommentsMain struct {
+	Cursor     CommentsCursor    `json:"cursor"`      // 游标信息
+	Replies    []*Comment        `json:"replies"`     // 评论列表
+	TopReplies []*Comment        `json:"top_replies"` // 置顶评论列表
+	Upper      CommentsMainUpper `json:"upper"`       // UP主信息及置顶评论
+	Notice     *Notice           `json:"notice"`      // 评论区公告信息
+	Config     CommentsConfig    `json:"config"`      // 评论区显示控制
+	Control    CommentsControl   `json:"control"`     // 评论区输入属性
+}
+
+// GetCommentsMain 获取评论区明细（懒加载），使用游标（next_offset）分页，没有 GetCommentsDetail 的页数限制
+func (c *Client) GetCommentsMain(param GetCommentsMainParam) (*CommentsMain, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/v2/reply/wbi/main"
+	)
+	return execute[*CommentsMain](c, method, url, param, fillWbiHandler(c.wbi, c.GetCookies()))
+}
@@ -0,0 +1,262 @@
+package bilibili
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// CommentNode 评论树中的一个节点
+type CommentNode struct {
+	*Comment                // 评论本身。其中的 Replies 预览会被置空，请使用 Children
+	Children []*CommentNode // 直接回复该评论的子评论，按发送时间排序
+}
+
+// CommentTree 评论区的完整快照
+type CommentTree struct {
+	Type    int            // 评论区类型代码
+	Oid     int            // 目标评论区 id
+	Roots   []*CommentNode // 一级评论，按抓取顺序排列（置顶评论在最前）
+	Orphans []*CommentNode // 父评论和根评论都无法获取（例如已被删除）的回复，按发送时间排序
+	Count   int            // 去重后的评论总条数，包括 Orphans 及其子评论
+}
+
+// Walk 深度优先遍历评论树，先遍历 Roots 再遍历 Orphans ，depth 从0开始，一级评论和 Orphans 中的评论为0
+func (t *CommentTree) Walk(f func(node *CommentNode, depth int)) {
+	var walk func(nodes []*CommentNode, depth int)
+	walk = func(nodes []*CommentNode, depth int) {
+		for _, node := range nodes {
+			f(node, depth)
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(t.Roots, 0)
+	walk(t.Orphans, 0)
+}
+
+// CommentCrawlProgress 评论抓取进度
+type CommentCrawlProgress struct {
+	Roots    int // 已抓取的一级评论数
+	Replies  int // 已抓取的二级及以上评论数
+	AllCount int // B站返回的评论总数，仅供参考，其中可能包含已删除或被折叠而无法获取的评论
+}
+
+// CommentCrawler 评论区爬虫。使用游标分页的 GetCommentsMain 遍历一级评论，
+// 再通过 GetCommentReply 并发展开每条一级评论下的所有回复，最终组装成一棵去重后的评论树
+type CommentCrawler struct {
+	client      *Client
+	mode        int
+	concurrency int
+	pacer       pacer
+	onProgress  func(CommentCrawlProgress)
+}
+
+// NewCommentCrawler 返回一个评论区爬虫，默认按时间排序抓取，展开回复的并发数为4
+func (c *Client) NewCommentCrawler() *CommentCrawler {
+	return &CommentCrawler{
+		client:      c,
+		mode:        2,
+		concurrency: 4,
+	}
+}
+
+// WithMode 设置一级评论的排序方式，见 GetCommentsMainParam.Mode
+func (cc *CommentCrawler) WithMode(mode int) *CommentCrawler {
+	cc.mode = mode
+	return cc
+}
+
+// WithConcurrency 设置展开回复时的最大并发请求数
+func (cc *CommentCrawler) WithConcurrency(concurrency int) *CommentCrawler {
+	cc.concurrency = max(concurrency, 1)
+	return cc
+}
+
+// WithInterval 设置相邻两次请求之间的最小间隔，所有并发的请求共用这个间隔。默认不限制，抓取评论很多的评论区时建议设置以免触发风控
+func (cc *CommentCrawler) WithInterval(interval time.Duration) *CommentCrawler {
+	cc.pacer.interval = interval
+	return cc
+}
+
+// WithProgress 设置进度回调。每抓取一页评论后调用一次，回调会被串行调用，请不要在回调中做耗时操作
+func (cc *CommentCrawler) WithProgress(onProgress func(CommentCrawlProgress)) *CommentCrawler {
+	cc.onProgress = onProgress
+	return cc
+}
+
+// Crawl 抓取指定评论区的全部评论。typ 为评论区类型代码，oid 为目标评论区 id
+func (cc *CommentCrawler) Crawl(ctx context.Context, typ, oid int) (*CommentTree, error) {
+	state := &commentCrawlState{
+		comments:   make(map[int]*Comment, 256),
+		onProgress: cc.onProgress,
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(cc.concurrency)
+	offset := ""
+	for gctx.Err() == nil {
+		pagination, err := json.Marshal(map[string]string{"offset": offset})
+		if err != nil {
+			_ = g.Wait()
+			return nil, errors.WithStack(err)
+		}
+		if err = cc.pacer.wait(gctx); err != nil {
+			break
+		}
+		page, err := cc.client.GetCommentsMain(GetCommentsMainParam{
+			Type:          typ,
+			Oid:           oid,
+			Mode:          cc.mode,
+			PaginationStr: string(pagination),
+		})
+		if err != nil {
+			_ = g.Wait()
+			return nil, err
+		}
+		roots := make([]*Comment, 0, len(page.TopReplies)+len(page.Replies)+1)
+		if page.Upper.Top != nil {
+			roots = append(roots, page.Upper.Top)
+		}
+		roots = append(roots, page.TopReplies...)
+		roots = append(roots, page.Replies...)
+		for _, root := range roots {
+			if !state.add(root, true) {
+				continue
+			}
+			for _, reply := range root.Replies {
+				state.add(reply, false)
+			}
+			if root.Rcount > len(root.Replies) {
+				g.Go(func() error { return cc.crawlReplies(gctx, state, typ, oid, root.Rpid) })
+			}
+		}
+		state.report(page.Cursor.AllCount)
+		offset = page.Cursor.PaginationReply.NextOffset
+		if page.Cursor.IsEnd || len(offset) == 0 || len(page.Replies) == 0 {
+			break
+		}
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return state.build(typ, oid), nil
+}
+
+// crawlReplies 展开一条一级评论下的所有回复
+func (cc *CommentCrawler) crawlReplies(ctx context.Context, state *commentCrawlState, typ, oid, root int) error {
+	const ps = 20 // 该接口单页最多只会返回20条
+	for pn := 1; ; pn++ {
+		if err := cc.pacer.wait(ctx); err != nil {
+			return err
+		}
+		result, err := cc.client.GetCommentReply(GetCommentReplyParam{
+			Type: typ,
+			Oid:  oid,
+			Root: root,
+			Ps:   ps,
+			Pn:   pn,
+		})
+		if err != nil {
+			return err
+		}
+		for _, reply := range result.Replies {
+			state.add(reply, false)
+		}
+		state.report(0)
+		if len(result.Replies) == 0 || pn*ps >= result.Page.Count {
+			return nil
+		}
+	}
+}
+
+type commentCrawlState struct {
+	mu         sync.Mutex
+	comments   map[int]*Comment
+	roots      []int
+	progress   CommentCrawlProgress
+	onProgress func(CommentCrawlProgress)
+}
+
+// add 记录一条评论，如果已经记录过则返回 false
+func (s *commentCrawlState) add(comment *Comment, isRoot bool) bool {
+	if comment == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.comments[comment.Rpid]; ok {
+		return false
+	}
+	s.comments[comment.Rpid] = comment
+	if isRoot {
+		s.roots = append(s.roots, comment.Rpid)
+		s.progress.Roots++
+	} else {
+		s.progress.Replies++
+	}
+	return true
+}
+
+func (s *commentCrawlState) report(allCount int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if allCount > 0 {
+		s.progress.AllCount = allCount
+	}
+	if s.onProgress != nil {
+		s.onProgress(s.progress)
+	}
+}
+
+func (s *commentCrawlState) build(typ, oid int) *CommentTree {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes := make(map[int]*CommentNode, len(s.comments))
+	for rpid, comment := range s.comments {
+		comment.Replies = nil
+		nodes[rpid] = &CommentNode{Comment: comment}
+	}
+	tree := &CommentTree{Type: typ, Oid: oid, Roots: make([]*CommentNode, 0, len(s.roots))}
+	for _, rpid := range s.roots {
+		tree.Roots = append(tree.Roots, nodes[rpid])
+	}
+	for _, node := range nodes {
+		if node.Root == 0 {
+			continue
+		}
+		// 优先挂在直接回复的评论下，父评论无法获取（例如已被删除）时挂在根评论下
+		parent, ok := nodes[node.Parent]
+		if !ok {
+			parent, ok = nodes[node.Root]
+		}
+		if !ok {
+			tree.Orphans = append(tree.Orphans, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	sortCommentNodes(tree.Orphans)
+	tree.Walk(func(node *CommentNode, _ int) {
+		sortCommentNodes(node.Children)
+		tree.Count++
+	})
+	return tree
+}
+
+// sortCommentNodes 按发送时间排序，时间相同时按 rpid 排序
+func sortCommentNodes(nodes []*CommentNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if a.Ctime != b.Ctime {
+			return a.Ctime < b.Ctime
+		}
+		return a.Rpid < b.Rpid
+	})
+}
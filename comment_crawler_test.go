@@ -0,0 +1,78 @@
+package bilibili
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCommentCrawlStateBuild(t *testing.T) {
+	state := &commentCrawlState{comments: make(map[int]*Comment)}
+	root := &Comment{Rpid: 1, Ctime: 100}
+	// 一级评论的回复预览会与展开的回复重复
+	root.Replies = []*Comment{{Rpid: 11, Root: 1, Parent: 1, Ctime: 120}}
+	if !state.add(root, true) || state.add(&Comment{Rpid: 1}, true) {
+		t.Fatal("root should be added exactly once")
+	}
+	for _, reply := range root.Replies {
+		state.add(reply, false)
+	}
+	for _, reply := range []*Comment{
+		{Rpid: 12, Root: 1, Parent: 1, Ctime: 110},
+		{Rpid: 11, Root: 1, Parent: 1, Ctime: 120},
+		{Rpid: 13, Root: 1, Parent: 11, Ctime: 130},
+		{Rpid: 14, Root: 1, Parent: 99, Ctime: 140}, // 父评论已被删除，挂在根评论下
+		{Rpid: 21, Root: 2, Parent: 2, Ctime: 150},  // 根评论无法获取
+		{Rpid: 22, Root: 2, Parent: 21, Ctime: 160},
+	} {
+		state.add(reply, false)
+	}
+	if state.progress.Roots != 1 || state.progress.Replies != 6 {
+		t.Fatalf("unexpected progress: %+v", state.progress)
+	}
+	tree := state.build(1, 2)
+	if tree.Count != 7 || len(tree.Roots) != 1 || tree.Roots[0].Replies != nil {
+		t.Fatalf("unexpected tree: %+v", tree)
+	}
+	children := tree.Roots[0].Children
+	if len(children) != 3 || children[0].Rpid != 12 || children[1].Rpid != 11 || children[2].Rpid != 14 {
+		t.Fatalf("unexpected children: %+v", children)
+	}
+	if len(children[1].Children) != 1 || children[1].Children[0].Rpid != 13 {
+		t.Fatalf("unexpected grandchildren: %+v", children[1].Children)
+	}
+	if len(tree.Orphans) != 1 || tree.Orphans[0].Rpid != 21 || len(tree.Orphans[0].Children) != 1 {
+		t.Fatalf("unexpected orphans: %+v", tree.Orphans)
+	}
+	var walked []int
+	tree.Walk(func(node *CommentNode, depth int) {
+		walked = append(walked, node.Rpid*10+depth)
+	})
+	expected := []int{10, 121, 111, 132, 141, 210, 221}
+	if len(walked) != len(expected) {
+		t.Fatalf("unexpected walk: %v", walked)
+	}
+	for i := range expected {
+		if walked[i] != expected[i] {
+			t.Fatalf("unexpected walk: %v", walked)
+		}
+	}
+}
+
+func TestPacer(t *testing.T) {
+	p := &pacer{interval: 20 * time.Millisecond}
+	start := time.Now()
+	for range 3 {
+		if err := p.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected at least 40ms, got %s", elapsed)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.wait(ctx); err == nil {
+		t.Fatal("expected error after cancel")
+	}
+}
@@ -0,0 +1,238 @@
+package bilibili
+
+import (
+	"github.com/go-resty/resty/v2"
+)
+
+type UpStat struct {
+	IncrClick  int `json:"incr_click"`  // 昨日新增播放数
+	IncrDm     int `json:"incr_dm"`     // 昨日新增弹幕数
+	IncrFans   int `json:"incr_fans"`   // 昨日新增粉丝数
+	IncrReply  int `json:"incr_reply"`  // 昨日新增评论数
+	IncCoin    int `json:"inc_coin"`    // 昨日新增投币数
+	IncElec    int `json:"inc_elec"`    // 昨日新增充电数
+	IncFav     int `json:"inc_fav"`     // 昨日新增收藏数
+	IncLike    int `json:"inc_like"`    // 昨日新增点赞数
+	IncShare   int `json:"inc_share"`   // 昨日新增分享数
+	TotalClick int `json:"total_click"` // 总播放数
+	TotalCoin  int `json:"total_coin"`  // 总投币数
+	TotalDm    int `json:"total_dm"`    // 总弹幕数
+	TotalElec  int `json:"total_elec"`  // 总充电数
+	TotalFans  int `json:"total_fans"`  // 总粉丝数
+	TotalFav   int `json:"total_fav"`   // 总收藏数
+	TotalLike  int `json:"total_like"`  // 总点赞数
+	TotalReply int `json:"total_reply"` // 总评论数
+	TotalShare int `json:"total_share"` // 总分享数
+}
+
+// GetUpStat 获取自己的稿件总数据以及昨日增量
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/creativecenter/statistics&data.md
+func (c *Client) GetUpStat() (*UpStat, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/index/stat"
+	)
+	return execute[*UpStat](c, method, url, nil)
+}
+
+// 数据类型，见 GetUpStatTrendParam.Type
+const (
+	UpStatTypePlay    = 1 // 播放
+	UpStatTypeDanmaku = 2 // 弹幕
+	UpStatTypeReply   = 3 // 评论
+	UpStatTypeShare   = 4 // 分享
+	UpStatTypeCoin    = 5 // 投币
+	UpStatTypeFav     = 6 // 收藏
+	UpStatTypeElec    = 7 // 充电
+	UpStatTypeLike    = 8 // 点赞
+)
+
+type GetUpStatTrendParam struct {
+	Type int `json:"type"` // 数据类型。见 UpStatTypePlay 等常量
+}
+
+type UpStatTrendItem struct {
+	DateKey  int `json:"date_key"`  // 日期。当天0点的时间戳
+	TotalInc int `json:"total_inc"` // 当天的增量
+}
+
+// GetUpStatTrend 获取自己最近30天某项数据的每日增量
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/creativecenter/statistics&data.md
+func (c *Client) GetUpStatTrend(param GetUpStatTrendParam) ([]UpStatTrendItem, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/data/pandect"
+	)
+	return execute[[]UpStatTrendItem](c, method, url, param)
+}
+
+type GetCreativeArchiveIncrementsParam struct {
+	Type int `json:"type"` // 数据类型。见 UpStatTypePlay 等常量
+}
+
+type CreativeArchiveIncrement struct {
+	Aid         int    `json:"aid"`         // 稿件avid
+	Bvid        string `json:"bvid"`        // 稿件bvid
+	Title       string `json:"title"`       // 稿件标题
+	Daytime     int    `json:"daytime"`     // 日期。当天0点的时间戳
+	Ptime       int    `json:"ptime"`       // 发布时间。时间戳
+	Incr        int    `json:"incr"`        // 当天的增量
+	Interactive int    `json:"interactive"` // 是否为互动视频。0：否。1：是
+}
+
+type CreativeDailyIncrement struct {
+	ArcInc   []CreativeArchiveIncrement `json:"arc_inc"`   // 当天有增量的稿件
+	TotalInc int                        `json:"total_inc"` // 当天的总增量
+}
+
+// GetCreativeArchiveIncrements 获取自己最近每天某项数据增量最多的稿件，返回值的键为日期，格式为 20060102
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/creativecenter/statistics&data.md
+func (c *Client) GetCreativeArchiveIncrements(param GetCreativeArchiveIncrementsParam) (map[string]CreativeDailyIncrement, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/data/survey"
+	)
+	return execute[map[string]CreativeDailyIncrement](c, method, url, param)
+}
+
+type CreativeArchiveStat struct {
+	Aid      int `json:"aid"`      // 稿件avid
+	View     int `json:"view"`     // 播放数
+	Danmaku  int `json:"danmaku"`  // 弹幕数
+	Reply    int `json:"reply"`    // 评论数
+	Favorite int `json:"favorite"` // 收藏数
+	Coin     int `json:"coin"`     // 投币数
+	Share    int `json:"share"`    // 分享数
+	Like     int `json:"like"`     // 点赞数
+}
+
+type CreativeArchive struct {
+	Aid       int    `json:"aid"`        // 稿件avid
+	Bvid      string `json:"bvid"`       // 稿件bvid
+	Title     string `json:"title"`      // 稿件标题
+	Cover     string `json:"cover"`      // 封面url
+	Tid       int    `json:"tid"`        // 分区tid
+	Duration  int    `json:"duration"`   // 视频时长。单位为秒
+	Copyright int    `json:"copyright"`  // 1：自制。2：转载
+	State     int    `json:"state"`      // 稿件状态。0：开放浏览。-2：被退回。-30：审核中
+	StateDesc string `json:"state_desc"` // 稿件状态描述
+	Ptime     int    `json:"ptime"`      // 发布时间。时间戳
+	Ctime     int    `json:"ctime"`      // 投稿时间。时间戳
+}
+
+type CreativeArchiveItem struct {
+	Archive CreativeArchive     `json:"Archive"` // 稿件信息
+	Stat    CreativeArchiveStat `json:"stat"`    // 稿件数据
+}
+
+type GetCreativeArchivesParam struct {
+	Status string `json:"status,omitempty" request:"query,omitempty"` // 稿件状态。is_pubing：进行中。pubed：已通过。not_pubed：未通过。多个用逗号分隔，默认为全部
+	Pn     int    `json:"pn" request:"query,default=1"`               // 页码
+	Ps     int    `json:"ps" request:"query,default=10"`              // 每页项数。最大为50
+}
+
+type CreativeArchives struct {
+	ArcAudits []CreativeArchiveItem `json:"arc_audits"` // 稿件列表
+	Page      struct {
+		Pn    int `json:"pn"`    // 当前页码
+		Ps    int `json:"ps"`    // 每页项数
+		Count int `json:"count"` // 稿件总数
+	} `json:"page"` // 分页信息
+}
+
+// GetCreativeArchives 获取自己的稿件列表以及每个稿件的累计数据
+func (c *Client) GetCreativeArchives(param GetCreativeArchivesParam) (*CreativeArchives, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/archives"
+	)
+	return execute[*CreativeArchives](c, method, url, param)
+}
+
+type CreativeArchiveDataParam struct {
+	Bvid string `json:"bvid"` // 稿件bvid
+}
+
+type CreativeArchiveTrendItem struct {
+	DateKey int `json:"date_key"` // 日期。当天0点的时间戳
+	View    int `json:"view"`     // 当天新增播放数
+	Danmaku int `json:"danmaku"`  // 当天新增弹幕数
+	Reply   int `json:"reply"`    // 当天新增评论数
+	Fav     int `json:"fav"`      // 当天新增收藏数
+	Coin    int `json:"coin"`     // 当天新增投币数
+	Share   int `json:"share"`    // 当天新增分享数
+	Like    int `json:"like"`     // 当天新增点赞数
+}
+
+type CreativeArchiveData struct {
+	Stat  CreativeArchiveStat        `json:"stat"`  // 稿件的累计数据
+	Trend []CreativeArchiveTrendItem `json:"trend"` // 稿件最近的每日增量，按日期从早到晚排列
+}
+
+// GetCreativeArchiveData 获取自己的单个稿件的累计数据和每日增量
+func (c *Client) GetCreativeArchiveData(param CreativeArchiveDataParam) (*CreativeArchiveData, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/data/archive"
+	)
+	return execute[*CreativeArchiveData](c, method, url, param)
+}
+
+type CreativeRate struct {
+	Name  string  `json:"name"`  // 名称，例如性别、年龄段、地区或者来源
+	Value int     `json:"value"` // 人数
+	Rate  float64 `json:"rate"`  // 占比，范围为0到1
+}
+
+type CreativeFansSummary struct {
+	Total    int `json:"total"`     // 粉丝总数
+	Inc      int `json:"inc"`       // 昨日新增粉丝数
+	Dec      int `json:"dec"`       // 昨日取关数
+	Active   int `json:"active"`    // 活跃粉丝数
+	LiveFans int `json:"live_fans"` // 直播粉丝数
+}
+
+type CreativeFansTrendItem struct {
+	DateKey int `json:"date_key"` // 日期。当天0点的时间戳
+	Total   int `json:"total"`    // 当天的粉丝总数
+	Inc     int `json:"inc"`      // 当天新增粉丝数
+	Dec     int `json:"dec"`      // 当天取关数
+}
+
+type CreativeFansData struct {
+	Summary CreativeFansSummary     `json:"summary"` // 粉丝概况
+	Trend   []CreativeFansTrendItem `json:"trend"`   // 最近的每日粉丝变化，按日期从早到晚排列
+	Source  []CreativeRate          `json:"source"`  // 新增粉丝的来源，例如视频、专栏、直播、空间
+}
+
+// GetCreativeFansData 获取自己的粉丝概况、粉丝增长趋势以及新增粉丝的来源
+func (c *Client) GetCreativeFansData() (*CreativeFansData, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/data/fan"
+	)
+	return execute[*CreativeFansData](c, method, url, nil)
+}
+
+type GetViewerPortraitParam struct {
+	Type int `json:"type,omitempty" request:"query,omitempty"` // 观众类型。0：粉丝。1：全部观众。默认为粉丝
+}
+
+type ViewerPortrait struct {
+	Gender []CreativeRate `json:"gender"` // 性别分布
+	Age    []CreativeRate `json:"age"`    // 年龄分布
+	Region []CreativeRate `json:"region"` // 地区分布
+	Device []CreativeRate `json:"device"` // 观看设备分布
+}
+
+// GetViewerPortrait 获取自己的观众画像，包括性别、年龄和地区分布
+func (c *Client) GetViewerPortrait(param GetViewerPortraitParam) (*ViewerPortrait, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x/web/data/base"
+	)
+	return execute[*ViewerPortrait](c, method, url, param)
+}
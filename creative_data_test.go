@@ -0,0 +1,79 @@
+package bilibili
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCreativeData(t *testing.T) {
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/web/data/pandect": func(params url.Values) (any, error) {
+			if params.Get("type") != "1" {
+				t.Errorf("unexpected type: %s", params.Get("type"))
+			}
+			return []map[string]any{{"date_key": 1717171200, "total_inc": 12}}, nil
+		},
+		"/x/web/data/survey": func(params url.Values) (any, error) {
+			if params.Get("type") != "8" {
+				t.Errorf("unexpected type: %s", params.Get("type"))
+			}
+			return map[string]any{"20240601": map[string]any{
+				"arc_inc":   []map[string]any{{"aid": 2, "bvid": "BV1xx", "title": "视频", "daytime": 1717171200, "incr": 5}},
+				"total_inc": 7,
+			}}, nil
+		},
+		"/x/web/data/archive": func(params url.Values) (any, error) {
+			if params.Get("bvid") != "BV1xx" {
+				t.Errorf("unexpected bvid: %s", params.Get("bvid"))
+			}
+			return map[string]any{
+				"stat":  map[string]any{"aid": 2, "view": 100, "like": 3},
+				"trend": []map[string]any{{"date_key": 1717171200, "view": 10}, {"date_key": 1717257600, "view": 20}},
+			}, nil
+		},
+		"/x/web/data/fan": func(url.Values) (any, error) {
+			return map[string]any{
+				"summary": map[string]any{"total": 1000, "inc": 10, "dec": 2},
+				"trend":   []map[string]any{{"date_key": 1717171200, "total": 1000, "inc": 10, "dec": 2}},
+				"source":  []map[string]any{{"name": "视频", "value": 8, "rate": 0.8}},
+			}, nil
+		},
+		"/x/web/data/base": func(params url.Values) (any, error) {
+			if params.Get("type") != "1" {
+				t.Errorf("unexpected type: %s", params.Get("type"))
+			}
+			return map[string]any{
+				"gender": []map[string]any{{"name": "男", "value": 6, "rate": 0.6}, {"name": "女", "value": 4, "rate": 0.4}},
+				"region": []map[string]any{{"name": "上海", "value": 3, "rate": 0.3}},
+			}, nil
+		},
+	})
+
+	trend, err := c.GetUpStatTrend(GetUpStatTrendParam{Type: UpStatTypePlay})
+	if err != nil || len(trend) != 1 || trend[0].TotalInc != 12 {
+		t.Fatalf("unexpected trend: %+v, %v", trend, err)
+	}
+
+	increments, err := c.GetCreativeArchiveIncrements(GetCreativeArchiveIncrementsParam{Type: UpStatTypeLike})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if day := increments["20240601"]; day.TotalInc != 7 || len(day.ArcInc) != 1 || day.ArcInc[0].Bvid != "BV1xx" || day.ArcInc[0].Incr != 5 {
+		t.Fatalf("unexpected increments: %+v", increments)
+	}
+
+	archive, err := c.GetCreativeArchiveData(CreativeArchiveDataParam{Bvid: "BV1xx"})
+	if err != nil || archive.Stat.View != 100 || len(archive.Trend) != 2 || archive.Trend[1].View != 20 {
+		t.Fatalf("unexpected archive data: %+v, %v", archive, err)
+	}
+
+	fans, err := c.GetCreativeFansData()
+	if err != nil || fans.Summary.Total != 1000 || len(fans.Trend) != 1 || fans.Source[0].Name != "视频" || fans.Source[0].Rate != 0.8 {
+		t.Fatalf("unexpected fans data: %+v, %v", fans, err)
+	}
+
+	portrait, err := c.GetViewerPortrait(GetViewerPortraitParam{Type: 1})
+	if err != nil || len(portrait.Gender) != 2 || portrait.Region[0].Name != "上海" || len(portrait.Age) != 0 {
+		t.Fatalf("unexpected portrait: %+v, %v", portrait, err)
+	}
+}
@@ -0,0 +1,398 @@
+package bilibili
+
+import (
+	"slices"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+type CreativeSeason struct {
+	Id             int    `json:"id"`               // 合集id
+	Title          string `json:"title"`            // 合集标题
+	Desc           string `json:"desc"`             // 合集简介
+	Cover          string `json:"cover"`            // 合集封面url
+	IsEnd          int    `json:"isEnd"`            // 是否已完结。0：连载中。1：已完结
+	Mid            int    `json:"mid"`              // UP主mid
+	IsAct          int    `json:"isAct"`            // 是否为活动合集
+	IsPay          int    `json:"is_pay"`           // 是否为付费合集
+	State          int    `json:"state"`            // 审核状态。0：已通过。-6：审核中。-4：未通过
+	PartState      int    `json:"partState"`        // 部分审核状态
+	SignState      int    `json:"signState"`        // 签约状态
+	RejectReason   string `json:"rejectReason"`     // 未通过审核的原因
+	Ctime          int    `json:"ctime"`            // 创建时间。时间戳
+	Mtime          int    `json:"mtime"`            // 修改时间。时间戳
+	NoSection      int    `json:"no_section"`       // 是否不分小节。1：只有一个默认小节，不显示小节
+	Forbid         int    `json:"forbid"`           // 是否被禁止
+	ProtocolId     string `json:"protocol_id"`      // 协议id
+	EpNum          int    `json:"ep_num"`           // 视频数量
+	SeasonPrice    int    `json:"season_price"`     // 合集价格。0为免费
+	IsOpened       int    `json:"is_opened"`        // 是否公开
+	HasChargingPay int    `json:"has_charging_pay"` // 是否包含充电专属视频
+}
+
+type CreativeSeasonSection struct {
+	Id           int    `json:"id"`           // 小节id
+	Type         int    `json:"type"`         // 小节类型
+	SeasonId     int    `json:"seasonId"`     // 所属合集id
+	Title        string `json:"title"`        // 小节标题
+	Order        int    `json:"order"`        // 小节在合集中的排序
+	State        int    `json:"state"`        // 审核状态
+	PartState    int    `json:"partState"`    // 部分审核状态
+	RejectReason string `json:"rejectReason"` // 未通过审核的原因
+	Ctime        int    `json:"ctime"`        // 创建时间。时间戳
+	Mtime        int    `json:"mtime"`        // 修改时间。时间戳
+	EpCount      int    `json:"epCount"`      // 视频数量
+	Cover        string `json:"cover"`        // 小节封面url
+}
+
+type CreativeSeasonEpisode struct {
+	Id           int    `json:"id"`           // 视频在合集中的id，删除和排序时使用
+	Title        string `json:"title"`        // 视频在合集中的标题
+	Aid          int    `json:"aid"`          // 稿件avid
+	Bvid         string `json:"bvid"`         // 稿件bvid
+	Cid          int    `json:"cid"`          // 视频cid
+	SeasonId     int    `json:"seasonId"`     // 所属合集id
+	SectionId    int    `json:"sectionId"`    // 所属小节id
+	Order        int    `json:"order"`        // 视频在小节中的排序
+	VideoTitle   string `json:"videoTitle"`   // 分P标题
+	ArchiveTitle string `json:"archiveTitle"` // 稿件标题
+	ArchiveState int    `json:"archiveState"` // 稿件状态
+	RejectReason string `json:"rejectReason"` // 未通过审核的原因
+	State        int    `json:"state"`        // 审核状态
+	Cover        string `json:"cover"`        // 封面url
+	IsFree       int    `json:"is_free"`      // 是否免费
+}
+
+type CreativeSeasonSections struct {
+	Sections []CreativeSeasonSection `json:"sections"` // 小节列表
+}
+
+type CreativeSeasonDetail struct {
+	Season       CreativeSeason          `json:"season"`        // 合集信息
+	Sections     CreativeSeasonSections  `json:"sections"`      // 小节信息
+	PartEpisodes []CreativeSeasonEpisode `json:"part_episodes"` // 部分视频
+}
+
+type GetCreativeSeasonsParam struct {
+	Pn    int    `json:"pn" request:"query,default=1"`       // 页码
+	Ps    int    `json:"ps" request:"query,default=30"`      // 每页项数
+	Order string `json:"order" request:"query,default=desc"` // 排序顺序。desc：降序。asc：升序
+	Sort  string `json:"sort" request:"query,default=mtime"` // 排序方式。mtime：修改时间。ctime：创建时间
+}
+
+type CreativeSeasons struct {
+	Seasons []CreativeSeasonDetail `json:"seasons"` // 合集列表
+	Total   int                    `json:"total"`   // 合集总数
+}
+
+// GetCreativeSeasons 获取自己在创作中心创建的合集列表
+func (c *Client) GetCreativeSeasons(param GetCreativeSeasonsParam) (*CreativeSeasons, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x2/creative/web/seasons"
+	)
+	return execute[*CreativeSeasons](c, method, url, param)
+}
+
+type CreativeIdParam struct {
+	Id int `json:"id"` // 合集id、小节id或者合集中的视频id
+}
+
+// GetCreativeSeason 获取自己的合集详情，Id 为合集id
+func (c *Client) GetCreativeSeason(param CreativeIdParam) (*CreativeSeasonDetail, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x2/creative/web/season"
+	)
+	return execute[*CreativeSeasonDetail](c, method, url, param)
+}
+
+type AddCreativeSeasonParam struct {
+	Title       string `json:"title"`                                    // 合集标题
+	Desc        string `json:"desc,omitempty" request:"query,omitempty"` // 合集简介
+	Cover       string `json:"cover"`                                    // 合集封面url
+	SeasonPrice int    `json:"season_price"`                             // 合集价格。0为免费
+}
+
+// AddCreativeSeason 新建合集，返回合集id。新建的合集会自动带有一个默认小节
+func (c *Client) AddCreativeSeason(param AddCreativeSeasonParam) (int, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/add"
+	)
+	return execute[int](c, method, url, param, fillCsrf(c))
+}
+
+type CreativeSort struct {
+	Id   int `json:"id"`   // 小节id或者合集中的视频id
+	Sort int `json:"sort"` // 排序，从1开始
+}
+
+type EditCreativeSeasonInfo struct {
+	Id          int    `json:"id"`           // 合集id
+	Title       string `json:"title"`        // 合集标题
+	Cover       string `json:"cover"`        // 合集封面url
+	Desc        string `json:"desc"`         // 合集简介
+	SeasonPrice int    `json:"season_price"` // 合集价格。0为免费
+	IsEnd       int    `json:"isEnd"`        // 是否已完结。0：连载中。1：已完结
+}
+
+type EditCreativeSeasonParam struct {
+	Season EditCreativeSeasonInfo `json:"season" request:"json"`                    // 合集信息
+	Sorts  []CreativeSort         `json:"sorts,omitempty" request:"json,omitempty"` // 小节的排序。为空则不修改
+}
+
+// EditCreativeSeason 修改合集信息和小节的排序
+func (c *Client) EditCreativeSeason(param EditCreativeSeasonParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/edit"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// DeleteCreativeSeason 删除合集，Id 为合集id。合集中的视频不会被删除
+func (c *Client) DeleteCreativeSeason(param CreativeIdParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/del"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type CreativeSectionDetail struct {
+	Section  CreativeSeasonSection   `json:"section"`  // 小节信息
+	Episodes []CreativeSeasonEpisode `json:"episodes"` // 小节中的视频，按排序排列
+}
+
+// GetCreativeSeasonSection 获取合集小节中的视频，Id 为小节id
+func (c *Client) GetCreativeSeasonSection(param CreativeIdParam) (*CreativeSectionDetail, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://member.bilibili.com/x2/creative/web/season/section"
+	)
+	return execute[*CreativeSectionDetail](c, method, url, param)
+}
+
+type AddCreativeSeasonSectionParam struct {
+	SeasonId int    `json:"seasonId" request:"json"` // 合集id
+	Title    string `json:"title" request:"json"`    // 小节标题
+}
+
+// AddCreativeSeasonSection 在合集中新建小节，返回小节id
+func (c *Client) AddCreativeSeasonSection(param AddCreativeSeasonSectionParam) (int, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/section/add"
+	)
+	return execute[int](c, method, url, param, fillCsrf(c))
+}
+
+type EditCreativeSeasonSectionInfo struct {
+	Id       int    `json:"id"`       // 小节id
+	Type     int    `json:"type"`     // 小节类型。与 CreativeSeasonSection.Type 相同
+	SeasonId int    `json:"seasonId"` // 合集id
+	Title    string `json:"title"`    // 小节标题
+}
+
+type EditCreativeSeasonSectionParam struct {
+	Section EditCreativeSeasonSectionInfo `json:"section" request:"json"`                   // 小节信息
+	Sorts   []CreativeSort                `json:"sorts,omitempty" request:"json,omitempty"` // 小节中视频的排序，Id 为 CreativeSeasonEpisode.Id 。为空则不修改
+}
+
+// EditCreativeSeasonSection 修改小节信息和小节中视频的排序
+func (c *Client) EditCreativeSeasonSection(param EditCreativeSeasonSectionParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/section/edit"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// DeleteCreativeSeasonSection 删除小节，Id 为小节id。合集中至少要保留一个小节
+func (c *Client) DeleteCreativeSeasonSection(param CreativeIdParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/section/del"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type CreativeSeasonEpisodeParam struct {
+	Title string `json:"title"` // 视频在合集中的标题，一般为稿件标题
+	Aid   int    `json:"aid"`   // 稿件avid
+	Cid   int    `json:"cid"`   // 视频cid
+}
+
+type AddCreativeSeasonEpisodesParam struct {
+	SectionId int                          `json:"sectionId" request:"json"` // 小节id
+	Episodes  []CreativeSeasonEpisodeParam `json:"episodes" request:"json"`  // 要加入的视频，会加在小节的末尾
+}
+
+// AddCreativeSeasonEpisodes 把自己的稿件加入合集的小节
+func (c *Client) AddCreativeSeasonEpisodes(param AddCreativeSeasonEpisodesParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/section/episodes/add"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// DeleteCreativeSeasonEpisode 把视频移出合集，Id 为 CreativeSeasonEpisode.Id
+func (c *Client) DeleteCreativeSeasonEpisode(param CreativeIdParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://member.bilibili.com/x2/creative/web/season/section/episode/del"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// CreativeSyncResult 按期望的顺序同步合集或者视频列表的结果
+type CreativeSyncResult struct {
+	Added     []string // 新加入的稿件bvid
+	Removed   []string // 被移出的稿件bvid
+	Reordered bool     // 是否修改了排序
+}
+
+// Changed 是否有任何修改
+func (r *CreativeSyncResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0 || r.Reordered
+}
+
+// diffCreativeOrder 对比当前和期望的稿件列表，返回需要加入和移出的稿件，以及在增删之后是否还需要重新排序
+func diffCreativeOrder(current, desired []string) (added, removed []string, reorder bool) {
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, bvid := range desired {
+		desiredSet[bvid] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	kept := make([]string, 0, len(current))
+	for _, bvid := range current {
+		currentSet[bvid] = struct{}{}
+		if _, ok := desiredSet[bvid]; ok {
+			kept = append(kept, bvid)
+		} else {
+			removed = append(removed, bvid)
+		}
+	}
+	for _, bvid := range desired {
+		if _, ok := currentSet[bvid]; !ok {
+			added = append(added, bvid)
+		}
+	}
+	// 新加入的稿件会加在末尾
+	after := slices.Concat(kept, added)
+	for i := range after {
+		if after[i] != desired[i] {
+			return added, removed, true
+		}
+	}
+	return added, removed, false
+}
+
+// SyncCreativeSeasonSection 把小节中的视频同步为 bvids ，并按 bvids 的顺序排列。会自动计算需要加入、移出的视频和排序。
+// 会先获取所有需要加入的视频的信息，再修改小节。修改中途出错时返回已经完成的修改以及错误。
+// 只支持每个稿件在小节中只有一个视频的情况，小节中有同一稿件的多个分P时返回错误，不会修改小节
+func (c *Client) SyncCreativeSeasonSection(sectionId int, bvids []string) (*CreativeSyncResult, error) {
+	if err := checkDuplicateBvids(bvids); err != nil {
+		return nil, err
+	}
+	detail, err := c.GetCreativeSeasonSection(CreativeIdParam{Id: sectionId})
+	if err != nil {
+		return nil, err
+	}
+	current := make([]string, 0, len(detail.Episodes))
+	episodeIds := make(map[string]int, len(detail.Episodes))
+	for _, episode := range detail.Episodes {
+		// 同一稿件的多个分P的bvid相同，无法按bvid区分
+		if _, ok := episodeIds[episode.Bvid]; ok {
+			return nil, errors.Errorf("小节中有同一稿件的多个分P，无法按bvid同步: %s", episode.Bvid)
+		}
+		current = append(current, episode.Bvid)
+		episodeIds[episode.Bvid] = episode.Id
+	}
+	added, removed, reorder := diffCreativeOrder(current, bvids)
+	episodes := make([]CreativeSeasonEpisodeParam, 0, len(added))
+	for _, bvid := range added {
+		info, err := c.GetVideoInfo(VideoParam{Bvid: bvid})
+		if err != nil {
+			return nil, err
+		}
+		episodes = append(episodes, CreativeSeasonEpisodeParam{Title: info.Title, Aid: info.Aid, Cid: info.Cid})
+	}
+	result := &CreativeSyncResult{}
+	for _, bvid := range removed {
+		if err = c.DeleteCreativeSeasonEpisode(CreativeIdParam{Id: episodeIds[bvid]}); err != nil {
+			return result, err
+		}
+		result.Removed = append(result.Removed, bvid)
+	}
+	if len(episodes) > 0 {
+		if err = c.AddCreativeSeasonEpisodes(AddCreativeSeasonEpisodesParam{SectionId: sectionId, Episodes: episodes}); err != nil {
+			return result, err
+		}
+		result.Added = added
+	}
+	if !reorder {
+		return result, nil
+	}
+	// 重新获取以得到新加入视频的id
+	if detail, err = c.GetCreativeSeasonSection(CreativeIdParam{Id: sectionId}); err != nil {
+		return result, err
+	}
+	for _, episode := range detail.Episodes {
+		episodeIds[episode.Bvid] = episode.Id
+	}
+	sorts := make([]CreativeSort, 0, len(bvids))
+	for i, bvid := range bvids {
+		id, ok := episodeIds[bvid]
+		if !ok {
+			return result, errors.Errorf("视频没有加入合集: %s", bvid)
+		}
+		sorts = append(sorts, CreativeSort{Id: id, Sort: i + 1})
+	}
+	err = c.EditCreativeSeasonSection(EditCreativeSeasonSectionParam{
+		Section: EditCreativeSeasonSectionInfo{
+			Id:       detail.Section.Id,
+			Type:     detail.Section.Type,
+			SeasonId: detail.Section.SeasonId,
+			Title:    detail.Section.Title,
+		},
+		Sorts: sorts,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Reordered = true
+	return result, nil
+}
+
+// SyncCreativeSeason 把只有一个小节的合集同步为 bvids ，见 SyncCreativeSeasonSection 。合集有多个小节时请对每个小节分别同步
+func (c *Client) SyncCreativeSeason(seasonId int, bvids []string) (*CreativeSyncResult, error) {
+	detail, err := c.GetCreativeSeason(CreativeIdParam{Id: seasonId})
+	if err != nil {
+		return nil, err
+	}
+	if len(detail.Sections.Sections) != 1 {
+		return nil, errors.Errorf("合集有%d个小节，请使用 SyncCreativeSeasonSection 分别同步", len(detail.Sections.Sections))
+	}
+	return c.SyncCreativeSeasonSection(detail.Sections.Sections[0].Id, bvids)
+}
+
+func checkDuplicateBvids(bvids []string) error {
+	seen := make(map[string]struct{}, len(bvids))
+	for _, bvid := range bvids {
+		if _, ok := seen[bvid]; ok {
+			return errors.Errorf("重复的稿件: %s", bvid)
+		}
+		seen[bvid] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package bilibili
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestDiffCreativeOrder(t *testing.T) {
+	for _, c := range []struct {
+		current, desired, added, removed []string
+		reorder                          bool
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, nil, nil, false},
+		{[]string{"a", "b", "c"}, []string{"a", "c", "d"}, []string{"d"}, []string{"b"}, false},
+		{[]string{"a", "b", "c"}, []string{"c", "a", "b"}, nil, nil, true},
+		{[]string{"a", "b"}, []string{"d", "a", "b"}, []string{"d"}, nil, true},
+		{nil, []string{"a", "b"}, []string{"a", "b"}, nil, false},
+		{[]string{"a", "b"}, nil, nil, []string{"a", "b"}, false},
+	} {
+		added, removed, reorder := diffCreativeOrder(c.current, c.desired)
+		if !slices.Equal(added, c.added) || !slices.Equal(removed, c.removed) || reorder != c.reorder {
+			t.Fatalf("%v -> %v: added %v, removed %v, reorder %v", c.current, c.desired, added, removed, reorder)
+		}
+	}
+	if checkDuplicateBvids([]string{"a", "b", "a"}) == nil {
+		t.Fatal("expected duplicate error")
+	}
+}
+
+func TestSyncCreativeSeasonSection(t *testing.T) {
+	var (
+		episodes   []map[string]any
+		calls      []string
+		infoFailed bool
+		addFailed  bool
+	)
+	record := func(name string, err error) stubHandler {
+		return func(url.Values) (any, error) {
+			calls = append(calls, name)
+			return nil, err
+		}
+	}
+	c := newStubClient(t, map[string]stubHandler{
+		"/x2/creative/web/season/section": func(url.Values) (any, error) {
+			return map[string]any{"section": map[string]any{"id": 5, "type": 1, "seasonId": 3, "title": "正片"}, "episodes": episodes}, nil
+		},
+		"/x/web-interface/view": func(params url.Values) (any, error) {
+			if infoFailed {
+				return nil, Error{Code: -404, Message: "啥都木有"}
+			}
+			return map[string]any{"bvid": params.Get("bvid"), "aid": 3, "cid": 30, "title": "c"}, nil
+		},
+		"/x2/creative/web/season/section/episode/del": record("del", nil),
+		"/x2/creative/web/season/section/episodes/add": func(url.Values) (any, error) {
+			calls = append(calls, "add")
+			if addFailed {
+				return nil, Error{Code: -400, Message: "请求错误"}
+			}
+			episodes = append(episodes, map[string]any{"id": 13, "bvid": "c"})
+			return nil, nil
+		},
+		"/x2/creative/web/season/section/edit": record("edit", nil),
+	})
+	reset := func() {
+		episodes = []map[string]any{{"id": 11, "bvid": "a"}, {"id": 12, "bvid": "b"}}
+		calls = nil
+	}
+
+	// 获取新加入视频的信息失败时不会修改小节
+	reset()
+	infoFailed = true
+	if result, err := c.SyncCreativeSeasonSection(5, []string{"c", "a"}); err == nil || result != nil || len(calls) != 0 {
+		t.Fatalf("unexpected result: %+v, %v, %v", result, err, calls)
+	}
+
+	// 中途出错时返回已经完成的修改
+	reset()
+	infoFailed, addFailed = false, true
+	result, err := c.SyncCreativeSeasonSection(5, []string{"c", "a"})
+	if err == nil || !slices.Equal(result.Removed, []string{"b"}) || len(result.Added) != 0 || result.Reordered {
+		t.Fatalf("unexpected partial result: %+v, %v", result, err)
+	}
+
+	reset()
+	addFailed = false
+	result, err = c.SyncCreativeSeasonSection(5, []string{"c", "a"})
+	if err != nil || !slices.Equal(result.Removed, []string{"b"}) || !slices.Equal(result.Added, []string{"c"}) || !result.Reordered {
+		t.Fatalf("unexpected result: %+v, %v", result, err)
+	}
+	if !slices.Equal(calls, []string{"del", "add", "edit"}) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+
+	// 只修改排序
+	reset()
+	result, err = c.SyncCreativeSeasonSection(5, []string{"b", "a"})
+	if err != nil || len(result.Added) != 0 || len(result.Removed) != 0 || !result.Reordered || !slices.Equal(calls, []string{"edit"}) {
+		t.Fatalf("unexpected reorder: %+v, %v, %v", result, err, calls)
+	}
+
+	// 顺序已经相同时不做任何修改
+	reset()
+	result, err = c.SyncCreativeSeasonSection(5, []string{"a", "b"})
+	if err != nil || result.Changed() || len(calls) != 0 {
+		t.Fatalf("unexpected result: %+v, %v, %v", result, err, calls)
+	}
+
+	// 同一稿件的多个分P无法按bvid同步
+	reset()
+	episodes = append(episodes, map[string]any{"id": 14, "bvid": "a", "cid": 2})
+	if result, err = c.SyncCreativeSeasonSection(5, []string{"b", "a"}); err == nil || result != nil || len(calls) != 0 {
+		t.Fatalf("expected multi-part error: %+v, %v, %v", result, err, calls)
+	}
+}
+
+func TestSyncVideoSeries(t *testing.T) {
+	var calls []string
+	record := func(name string) stubHandler {
+		return func(params url.Values) (any, error) {
+			calls = append(calls, name+":"+params.Get("aids"))
+			return nil, nil
+		}
+	}
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/series/archives": func(url.Values) (any, error) {
+			archives := []map[string]any{{"aid": 1, "bvid": Av2Bv(1)}, {"aid": 2, "bvid": Av2Bv(2)}}
+			return map[string]any{"archives": archives, "page": map[string]any{"total": len(archives)}}, nil
+		},
+		"/x/series/series/delArchives": record("del"),
+		"/x/series/series/addArchives": record("add"),
+	})
+
+	// bvid 格式错误时不会修改视频列表
+	if result, err := c.SyncVideoSeries(7, []string{Av2Bv(1), "BV1"}); err == nil || result != nil || len(calls) != 0 {
+		t.Fatalf("unexpected result: %+v, %v, %v", result, err, calls)
+	}
+
+	result, err := c.SyncVideoSeries(7, []string{Av2Bv(3), Av2Bv(1)})
+	if err != nil || !slices.Equal(result.Removed, []string{Av2Bv(2)}) || !slices.Equal(result.Added, []string{Av2Bv(3)}) {
+		t.Fatalf("unexpected result: %+v, %v", result, err)
+	}
+	if !slices.Equal(calls, []string{"del:2", "add:3"}) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
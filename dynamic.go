@@ -543,6 +543,82 @@ func (c *Client) CreateDynamic(param CreateDynamicParam) (*CreateDynamicResult,
 	return execute[*CreateDynamicResult](c, method, url, param, fillCsrf(c))
 }
 
+type DynamicPicture struct {
+	ImgSrc    string `json:"img_src"`    // 图片url，即 UploadDynamicBfs 返回的url
+	ImgWidth  int    `json:"img_width"`  // 图片宽度
+	ImgHeight int    `json:"img_height"` // 图片高度
+}
+
+type CreateDrawDynamicParam struct {
+	Biz         int              `json:"biz" request:"query,default=3"`                   // 3
+	Category    int              `json:"category" request:"query,default=3"`              // 3
+	Type        int              `json:"type"`                                            // 0
+	Pictures    []DynamicPicture `json:"pictures"`                                        // 图片列表，最多9张
+	Description string           `json:"description"`                                     // 动态内容
+	Content     string           `json:"content"`                                         // 动态内容，与 Description 相同
+	From        string           `json:"from" request:"query,default=create.dynamic.web"` // create.dynamic.web
+	Extension   string           `json:"extension,omitempty" request:"query,omitempty"`   // 位置信息，参考 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/dynamic/publish.md
+	AtUids      string           `json:"at_uids,omitempty" request:"query,omitempty"`     // 动态中 at 到的用户的 uid。使用逗号,分隔
+	AtControl   []FormatCtrl     `json:"at_control,omitempty" request:"query,omitempty"`  // 特殊格式控制 (如 at 别人时的蓝字体和链接)
+}
+
+// CreateDrawDynamic 发表图片动态，图片需要先通过 UploadDynamicBfs 上传
+func (c *Client) CreateDrawDynamic(param CreateDrawDynamicParam) (*CreateDynamicResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.vc.bilibili.com/dynamic_svr/v1/dynamic_svr/create_draw"
+	)
+	return execute[*CreateDynamicResult](c, method, url, param, fillCsrf(c))
+}
+
+type DynamicImageFile struct {
+	FileName string    // 文件名
+	File     io.Reader // 图片内容
+}
+
+// CreateImageDynamic 上传图片并发表图片动态，最多9张图片。 content 可以由 DynamicContentBuilder 生成，为 nil 时发表不带文字的动态
+func (c *Client) CreateImageDynamic(content *DynamicContent, images []DynamicImageFile) (*CreateDynamicResult, error) {
+	if len(images) == 0 || len(images) > 9 {
+		return nil, errors.Errorf("图片数量必须在1到9之间: %d", len(images))
+	}
+	if content == nil {
+		content = &DynamicContent{}
+	}
+	pictures := make([]DynamicPicture, 0, len(images))
+	for _, image := range images {
+		url, size, err := c.UploadDynamicBfs(image.FileName, image.File, "daily")
+		if err != nil {
+			return nil, err
+		}
+		pictures = append(pictures, DynamicPicture{ImgSrc: url, ImgWidth: size.Width, ImgHeight: size.Height})
+	}
+	return c.CreateDrawDynamic(CreateDrawDynamicParam{
+		Pictures:    pictures,
+		Description: content.Content,
+		Content:     content.Content,
+		AtUids:      content.AtUids,
+		AtControl:   content.Ctrl,
+	})
+}
+
+type RepostDynamicParam struct {
+	DynamicId int          `json:"dynamic_id"`                                    // 被转发的动态id
+	Content   string       `json:"content"`                                       // 转发时的评论内容
+	Extension string       `json:"extension,omitempty" request:"query,omitempty"` // 位置信息，参考 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/dynamic/publish.md
+	AtUids    string       `json:"at_uids,omitempty" request:"query,omitempty"`   // 评论中 at 到的用户的 uid。使用逗号,分隔
+	Ctrl      []FormatCtrl `json:"ctrl,omitempty" request:"query,omitempty"`      // 特殊格式控制 (如 at 别人时的蓝字体和链接)
+}
+
+// RepostDynamic 转发动态
+func (c *Client) RepostDynamic(param RepostDynamicParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.vc.bilibili.com/dynamic_repost/v1/dynamic_repost/repost"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
 // DynamicList 包含置顶及热门的动态列表
 //
 // TODO: 因为不清楚 attentions 字段（关注列表）的格式，暂未对此字段进行解析
@@ -954,16 +1030,18 @@ type DynamicItem struct {
 				Topic any `json:"topic"`
 			} `json:"module_dynamic"`
 		} `json:"modules"`
-		Type    string `json:"type"`
-		Visible bool   `json:"visible"`
+		Type    DynamicType `json:"type"`
+		Visible bool        `json:"visible"`
 	} `json:"orig,omitempty"`
-	Type    string `json:"type"`
-	Visible bool   `json:"visible"`
+	Type    DynamicType `json:"type"`
+	Visible bool        `json:"visible"`
 }
 
+// DynamicInfo 动态列表。 Items 是为了兼容而保留的原始结构，推荐使用强类型的 Dynamics
 type DynamicInfo struct {
 	HasMore        bool          `json:"has_more"`        // 是否有更多数据
 	Items          []DynamicItem `json:"items"`           // 数据数组
+	Dynamics       []*Dynamic    `json:"-"`               // 数据数组，与 Items 一一对应。解析失败的动态为 nil
 	Offset         string        `json:"offset"`          // 偏移量，等于items中最后一条记录的id，获取下一页时使用
 	UpdateBaseline string        `json:"update_baseline"` // 更新基线，等于items中第一条记录的id
 	UpdateNum      json.Number   `json:"update_num"`      // 本次获取获取到了多少条新动态，在更新基线以上的动态条数
@@ -0,0 +1,122 @@
+package bilibili
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// DynamicContent 构造好的动态内容，可以直接填入 CreateDynamicParam 、 CreateDrawDynamicParam 、 RepostDynamicParam 等参数中
+type DynamicContent struct {
+	Content string       // 动态内容
+	AtUids  string       // at 到的用户的 uid。使用逗号,分隔
+	Ctrl    []FormatCtrl // at 用户对应的特殊格式控制
+}
+
+type dynamicContentPart struct {
+	text string // 普通文本或表情
+	at   bool   // 是否为 at 用户
+	uid  int    // at 用户的 uid ，为0时需要通过昵称搜索
+	name string // at 用户的昵称
+}
+
+// DynamicContentBuilder 动态内容构造器。会自动计算 at 用户时 FormatCtrl 的 Location 和 Length ，
+// 它们是按字符（而不是字节）计算的
+type DynamicContentBuilder struct {
+	parts []dynamicContentPart
+}
+
+// NewDynamicContentBuilder 返回一个空的动态内容构造器
+func NewDynamicContentBuilder() *DynamicContentBuilder {
+	return &DynamicContentBuilder{}
+}
+
+// Text 追加普通文本
+func (b *DynamicContentBuilder) Text(text string) *DynamicContentBuilder {
+	b.parts = append(b.parts, dynamicContentPart{text: text})
+	return b
+}
+
+// Emote 追加表情，name 可以是 doge 或者 [doge] 的形式
+func (b *DynamicContentBuilder) Emote(name string) *DynamicContentBuilder {
+	if !strings.HasPrefix(name, "[") || !strings.HasSuffix(name, "]") {
+		name = "[" + name + "]"
+	}
+	b.parts = append(b.parts, dynamicContentPart{text: name})
+	return b
+}
+
+// At 追加 at 用户，已知对方 uid 时使用
+func (b *DynamicContentBuilder) At(uid int, name string) *DynamicContentBuilder {
+	b.parts = append(b.parts, dynamicContentPart{at: true, uid: uid, name: name})
+	return b
+}
+
+// AtName 追加 at 用户，在 Build 时通过 SearchDynamicAt 按昵称查找对方的 uid
+func (b *DynamicContentBuilder) AtName(name string) *DynamicContentBuilder {
+	b.parts = append(b.parts, dynamicContentPart{at: true, name: name})
+	return b
+}
+
+// Build 生成动态内容。如果使用了 AtName ，则需要已登录的 c 来搜索用户，否则 c 可以为 nil
+func (b *DynamicContentBuilder) Build(c *Client) (*DynamicContent, error) {
+	resolved := make(map[string]int)
+	var (
+		sb     strings.Builder
+		uids   []string
+		ctrl   []FormatCtrl
+		length int
+	)
+	for _, part := range b.parts {
+		if !part.at {
+			sb.WriteString(part.text)
+			length += utf8.RuneCountInString(part.text)
+			continue
+		}
+		uid := part.uid
+		if uid == 0 {
+			var ok bool
+			if uid, ok = resolved[part.name]; !ok {
+				var err error
+				if uid, err = searchDynamicAtUid(c, part.name); err != nil {
+					return nil, err
+				}
+				resolved[part.name] = uid
+			}
+		}
+		// at 的内容是“@昵称 ”，末尾的空格也算在蓝字的范围内
+		text := "@" + part.name + " "
+		textLength := utf8.RuneCountInString(text)
+		sb.WriteString(text)
+		uids = append(uids, strconv.Itoa(uid))
+		ctrl = append(ctrl, FormatCtrl{Location: length, Type: 1, Length: textLength, Data: strconv.Itoa(uid)})
+		length += textLength
+	}
+	return &DynamicContent{Content: sb.String(), AtUids: strings.Join(uids, ","), Ctrl: ctrl}, nil
+}
+
+// searchDynamicAtUid 通过 SearchDynamicAt 查找昵称完全一致的用户
+func searchDynamicAtUid(c *Client, name string) (int, error) {
+	if c == nil {
+		return 0, errors.Errorf("需要登录才能搜索用户: %s", name)
+	}
+	uid := cast.ToInt(c.getCookie("DedeUserID"))
+	if uid == 0 {
+		return 0, errors.New("B站登录过期")
+	}
+	result, err := c.SearchDynamicAt(SearchDynamicAtParam{Uid: uid, Keyword: name})
+	if err != nil {
+		return 0, err
+	}
+	for _, group := range result.Groups {
+		for _, item := range group.Items {
+			if item.Uname == name {
+				return item.Uid, nil
+			}
+		}
+	}
+	return 0, errors.Errorf("未找到用户: %s", name)
+}
@@ -0,0 +1,37 @@
+package bilibili
+
+import "testing"
+
+func TestDynamicContentBuilder(t *testing.T) {
+	content, err := NewDynamicContentBuilder().
+		Text("你好").
+		At(123, "小明").
+		Emote("doge").
+		Text("和").
+		At(456, "Alice").
+		Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Content != "你好@小明 [doge]和@Alice " {
+		t.Fatal("content not correct ", content.Content)
+	}
+	if content.AtUids != "123,456" {
+		t.Fatal("at_uids not correct ", content.AtUids)
+	}
+	expected := []FormatCtrl{
+		{Location: 2, Type: 1, Length: 4, Data: "123"},
+		{Location: 13, Type: 1, Length: 7, Data: "456"},
+	}
+	if len(content.Ctrl) != len(expected) {
+		t.Fatal("ctrl not correct ", content.Ctrl)
+	}
+	for i := range expected {
+		if content.Ctrl[i] != expected[i] {
+			t.Fatal("ctrl not correct ", content.Ctrl)
+		}
+	}
+	if _, err = NewDynamicContentBuilder().AtName("小明").Build(nil); err == nil {
+		t.Fatal("AtName without client should return error")
+	}
+}
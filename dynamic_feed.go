@@ -0,0 +1,215 @@
+package bilibili
+
+import (
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DynamicFeedType 动态列表的类型筛选
+type DynamicFeedType string
+
+const (
+	DynamicFeedTypeAll     DynamicFeedType = "all"     // 全部
+	DynamicFeedTypeVideo   DynamicFeedType = "video"   // 视频投稿
+	DynamicFeedTypePgc     DynamicFeedType = "pgc"     // 追番追剧
+	DynamicFeedTypeArticle DynamicFeedType = "article" // 专栏
+)
+
+type GetDynamicFeedParam struct {
+	Type           DynamicFeedType `json:"type,omitempty" request:"query,omitempty"`            // 动态类型。默认为 all
+	Offset         string          `json:"offset,omitempty" request:"query,omitempty"`          // 分页偏移量，即上一页的 DynamicInfo.Offset
+	UpdateBaseline string          `json:"update_baseline,omitempty" request:"query,omitempty"` // 更新基线，用于获取 DynamicInfo.UpdateNum
+	Page           int             `json:"page,omitempty" request:"query,omitempty"`            // 页码
+	TimezoneOffset int             `json:"timezone_offset" request:"query,default=-480"`        // -480
+	Features       string          `json:"features" request:"query,default=itemOpusStyle"`      // itemOpusStyle
+}
+
+// GetDynamicFeed 获取自己关注的所有人的动态（需要登录）
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/dynamic/all.md
+func (c *Client) GetDynamicFeed(param GetDynamicFeedParam) (*DynamicInfo, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/all"
+	)
+	return execute[*DynamicInfo](c, method, url, param)
+}
+
+type GetDynamicFeedUpdateParam struct {
+	Type           DynamicFeedType `json:"type,omitempty" request:"query,omitempty"` // 动态类型。默认为 all
+	UpdateBaseline string          `json:"update_baseline"`                          // 更新基线，即上次获取到的 DynamicInfo.UpdateBaseline
+}
+
+type DynamicFeedUpdate struct {
+	UpdateNum int `json:"update_num"` // 更新基线之后的新动态数
+}
+
+// GetDynamicFeedUpdate 检查自己关注的所有人在更新基线之后有多少条新动态（需要登录）
+func (c *Client) GetDynamicFeedUpdate(param GetDynamicFeedUpdateParam) (*DynamicFeedUpdate, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/all/update"
+	)
+	return execute[*DynamicFeedUpdate](c, method, url, param)
+}
+
+// DynamicFeedPoller 关注动态轮询器。每次 Poll 先通过 GetDynamicFeedUpdate 检查是否有新动态，
+// 有新动态时才翻页获取更新基线之后的动态，适合定时调用
+type DynamicFeedPoller struct {
+	client   *Client
+	typ      DynamicFeedType
+	tagIds   []int
+	tagsTTL  time.Duration
+	mids     map[int]struct{}
+	midsTime time.Time
+	baseline string
+}
+
+// NewDynamicFeedPoller 返回一个关注动态轮询器，typ 为空时获取全部类型的动态
+func (c *Client) NewDynamicFeedPoller(typ DynamicFeedType) *DynamicFeedPoller {
+	return &DynamicFeedPoller{client: c, typ: typ, tagsTTL: 10 * time.Minute}
+}
+
+// WithTags 只保留指定关注分组中的用户的动态，分组 id 见 GetRelationTags 。
+// 分组成员会在下一次 Poll 时重新获取，之后每隔 WithTagsTTL 设置的时间重新获取一次
+func (p *DynamicFeedPoller) WithTags(tagIds ...int) *DynamicFeedPoller {
+	p.tagIds = tagIds
+	p.mids = nil
+	return p
+}
+
+// WithTagsTTL 设置重新获取关注分组成员的间隔，默认为10分钟
+func (p *DynamicFeedPoller) WithTagsTTL(ttl time.Duration) *DynamicFeedPoller {
+	p.tagsTTL = ttl
+	return p
+}
+
+// WithBaseline 设置更新基线，通常是上次运行时 Baseline 的返回值，用于重启后继续轮询
+func (p *DynamicFeedPoller) WithBaseline(baseline string) *DynamicFeedPoller {
+	p.baseline = baseline
+	return p
+}
+
+// Baseline 返回当前的更新基线，可以持久化下来，下次通过 WithBaseline 恢复
+func (p *DynamicFeedPoller) Baseline() string {
+	return p.baseline
+}
+
+// Unread 返回当前更新基线之后的新动态数，不会改变更新基线。没有更新基线时返回0
+func (p *DynamicFeedPoller) Unread() (int, error) {
+	if len(p.baseline) == 0 {
+		return 0, nil
+	}
+	update, err := p.client.GetDynamicFeedUpdate(GetDynamicFeedUpdateParam{Type: p.typ, UpdateBaseline: p.baseline})
+	if err != nil {
+		return 0, err
+	}
+	return update.UpdateNum, nil
+}
+
+// Poll 获取更新基线之后的新动态，按从新到旧排列，并推进更新基线。
+// 没有更新基线时（第一次调用），只会记录当前的更新基线并返回空列表，以免把历史动态当作新动态
+func (p *DynamicFeedPoller) Poll() ([]*Dynamic, error) {
+	if len(p.baseline) == 0 {
+		info, err := p.client.GetDynamicFeed(GetDynamicFeedParam{Type: p.typ})
+		if err != nil {
+			return nil, err
+		}
+		p.baseline = info.UpdateBaseline
+		return nil, nil
+	}
+	unread, err := p.Unread()
+	if err != nil || unread == 0 {
+		return nil, err
+	}
+	if err = p.loadMids(); err != nil {
+		return nil, err
+	}
+	var (
+		result      []*Dynamic
+		newBaseline string
+		offset      string
+	)
+loop:
+	for page := 1; ; page++ {
+		var info *DynamicInfo
+		info, err = p.client.GetDynamicFeed(GetDynamicFeedParam{
+			Type:           p.typ,
+			Offset:         offset,
+			UpdateBaseline: p.baseline,
+			Page:           page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(newBaseline) == 0 {
+			newBaseline = info.UpdateBaseline
+		}
+		for _, d := range info.Dynamics {
+			if d == nil {
+				continue
+			}
+			if !dynamicIdGreater(d.Id(), p.baseline) {
+				break loop
+			}
+			if p.accept(d) {
+				result = append(result, d)
+			}
+		}
+		offset = info.Offset
+		if !info.HasMore || len(offset) == 0 {
+			break
+		}
+	}
+	if len(newBaseline) > 0 {
+		p.baseline = newBaseline
+	}
+	return result, nil
+}
+
+// loadMids 获取关注分组中的所有用户，距离上次获取超过 tagsTTL 时重新获取
+func (p *DynamicFeedPoller) loadMids() error {
+	if len(p.tagIds) == 0 || (p.mids != nil && time.Since(p.midsTime) < p.tagsTTL) {
+		return nil
+	}
+	const ps = 50
+	mids := make(map[int]struct{})
+	for _, tagId := range p.tagIds {
+		for pn := 1; ; pn++ {
+			users, err := p.client.GetRelationTagUsers(GetRelationTagUsersParam{Tagid: tagId, Ps: ps, Pn: pn})
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				mids[user.Mid] = struct{}{}
+			}
+			if len(users) < ps {
+				break
+			}
+		}
+	}
+	p.mids = mids
+	p.midsTime = time.Now()
+	return nil
+}
+
+func (p *DynamicFeedPoller) accept(d *Dynamic) bool {
+	if len(p.tagIds) == 0 {
+		return true
+	}
+	author := d.Author()
+	if author == nil {
+		return false
+	}
+	_, ok := p.mids[author.Uid()]
+	return ok
+}
+
+// dynamicIdGreater 比较两个动态id的大小。动态id可能超出 int64 的范围，因此按字符串比较
+func dynamicIdGreater(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
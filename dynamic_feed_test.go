@@ -0,0 +1,94 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func stubDynamic(id string, mid int) json.RawMessage {
+	return json.RawMessage(`{"id_str":"` + id + `","type":"DYNAMIC_TYPE_WORD","visible":true,"modules":{"module_author":{"mid":` +
+		strconv.Itoa(mid) + `}}}`)
+}
+
+func TestDynamicFeedPoller(t *testing.T) {
+	var (
+		pages     map[string][]json.RawMessage // offset -> items
+		baseline  string
+		updateNum int
+		members   []map[string]any
+		tagCalls  int
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/polymer/web-dynamic/v1/feed/all": func(params url.Values) (any, error) {
+			offset := params.Get("offset")
+			items := pages[offset]
+			next := ""
+			if len(items) > 0 {
+				var last struct {
+					IdStr string `json:"id_str"`
+				}
+				_ = json.Unmarshal(items[len(items)-1], &last)
+				next = last.IdStr
+			}
+			_, hasMore := pages[next]
+			return map[string]any{"has_more": hasMore, "items": items, "offset": next, "update_baseline": baseline}, nil
+		},
+		"/x/polymer/web-dynamic/v1/feed/all/update": func(params url.Values) (any, error) {
+			if params.Get("update_baseline") == "" {
+				t.Error("update_baseline is required")
+			}
+			return map[string]any{"update_num": updateNum}, nil
+		},
+		"/x/relation/tag": func(params url.Values) (any, error) {
+			tagCalls++
+			if params.Get("tagid") != "5" {
+				t.Errorf("unexpected tagid: %s", params.Get("tagid"))
+			}
+			return members, nil
+		},
+	})
+	poller := c.NewDynamicFeedPoller(DynamicFeedTypeAll).WithTags(5)
+
+	// 第一次只记录更新基线
+	baseline = "100"
+	pages = map[string][]json.RawMessage{"": {stubDynamic("100", 1), stubDynamic("99", 2)}}
+	if result, err := poller.Poll(); err != nil || len(result) != 0 || poller.Baseline() != "100" {
+		t.Fatalf("unexpected first poll: %v, %v, %s", result, err, poller.Baseline())
+	}
+
+	// 没有新动态时不翻页
+	updateNum = 0
+	pages = nil
+	if result, err := poller.Poll(); err != nil || len(result) != 0 || tagCalls != 0 {
+		t.Fatalf("unexpected empty poll: %v, %v", result, err)
+	}
+
+	// 跨页获取，遇到更新基线时停止，只保留分组中的用户
+	baseline, updateNum = "103", 3
+	members = []map[string]any{{"mid": 1}, {"mid": 3}}
+	pages = map[string][]json.RawMessage{
+		"":    {stubDynamic("103", 1), stubDynamic("102", 2)},
+		"102": {stubDynamic("101", 3), stubDynamic("100", 1)},
+		"100": {stubDynamic("99", 1)},
+	}
+	result, err := poller.Poll()
+	if err != nil || len(result) != 2 || result[0].Id() != "103" || result[1].Id() != "101" || poller.Baseline() != "103" {
+		t.Fatalf("unexpected poll: %v, %v, %s", result, err, poller.Baseline())
+	}
+
+	// 分组成员在 tagsTTL 内不会重新获取
+	baseline, updateNum = "104", 1
+	members = []map[string]any{{"mid": 1}, {"mid": 2}, {"mid": 3}}
+	pages = map[string][]json.RawMessage{"": {stubDynamic("104", 2), stubDynamic("103", 1)}}
+	if result, err = poller.Poll(); err != nil || len(result) != 0 || tagCalls != 1 {
+		t.Fatalf("unexpected poll within ttl: %v, %v, %d", result, err, tagCalls)
+	}
+
+	// 超过 tagsTTL 后重新获取分组成员
+	poller.WithTagsTTL(0).WithBaseline("103")
+	if result, err = poller.Poll(); err != nil || len(result) != 1 || result[0].Id() != "104" || tagCalls != 2 {
+		t.Fatalf("unexpected poll after ttl: %v, %v, %d", result, err, tagCalls)
+	}
+}
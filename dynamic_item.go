@@ -0,0 +1,623 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// DynamicType 动态类型
+type DynamicType string
+
+const (
+	DynamicTypeNone            DynamicType = "DYNAMIC_TYPE_NONE"             // 无效动态
+	DynamicTypeForward         DynamicType = "DYNAMIC_TYPE_FORWARD"          // 动态转发
+	DynamicTypeAv              DynamicType = "DYNAMIC_TYPE_AV"               // 投稿视频
+	DynamicTypePgc             DynamicType = "DYNAMIC_TYPE_PGC"              // 剧集（番剧、电影、纪录片）
+	DynamicTypeCourses         DynamicType = "DYNAMIC_TYPE_COURSES"          // 课程
+	DynamicTypeWord            DynamicType = "DYNAMIC_TYPE_WORD"             // 纯文字动态
+	DynamicTypeDraw            DynamicType = "DYNAMIC_TYPE_DRAW"             // 带图动态
+	DynamicTypeArticle         DynamicType = "DYNAMIC_TYPE_ARTICLE"          // 投稿专栏
+	DynamicTypeMusic           DynamicType = "DYNAMIC_TYPE_MUSIC"            // 音乐
+	DynamicTypeCommonSquare    DynamicType = "DYNAMIC_TYPE_COMMON_SQUARE"    // 装扮、剧集点评、普通分享
+	DynamicTypeCommonVertical  DynamicType = "DYNAMIC_TYPE_COMMON_VERTICAL"  // 垂直动态
+	DynamicTypeLive            DynamicType = "DYNAMIC_TYPE_LIVE"             // 直播间分享
+	DynamicTypeMedialist       DynamicType = "DYNAMIC_TYPE_MEDIALIST"        // 收藏夹
+	DynamicTypeCoursesSeason   DynamicType = "DYNAMIC_TYPE_COURSES_SEASON"   // 课程
+	DynamicTypeCoursesBatch    DynamicType = "DYNAMIC_TYPE_COURSES_BATCH"    // 课程
+	DynamicTypeAd              DynamicType = "DYNAMIC_TYPE_AD"               // 广告
+	DynamicTypeApplet          DynamicType = "DYNAMIC_TYPE_APPLET"           // 小程序
+	DynamicTypeSubscription    DynamicType = "DYNAMIC_TYPE_SUBSCRIPTION"     // 订阅
+	DynamicTypeLiveRcmd        DynamicType = "DYNAMIC_TYPE_LIVE_RCMD"        // 直播开播
+	DynamicTypeBanner          DynamicType = "DYNAMIC_TYPE_BANNER"           // 横幅
+	DynamicTypeUgcSeason       DynamicType = "DYNAMIC_TYPE_UGC_SEASON"       // 合集更新
+	DynamicTypeSubscriptionNew DynamicType = "DYNAMIC_TYPE_SUBSCRIPTION_NEW" // 订阅
+)
+
+// MajorType 动态主体类型
+type MajorType string
+
+const (
+	MajorTypeNone         MajorType = "MAJOR_TYPE_NONE"          // 动态失效
+	MajorTypeOpus         MajorType = "MAJOR_TYPE_OPUS"          // 图文动态
+	MajorTypeArchive      MajorType = "MAJOR_TYPE_ARCHIVE"       // 视频
+	MajorTypePgc          MajorType = "MAJOR_TYPE_PGC"           // 剧集更新
+	MajorTypeCourses      MajorType = "MAJOR_TYPE_COURSES"       // 课程
+	MajorTypeDraw         MajorType = "MAJOR_TYPE_DRAW"          // 带图动态
+	MajorTypeArticle      MajorType = "MAJOR_TYPE_ARTICLE"       // 专栏
+	MajorTypeMusic        MajorType = "MAJOR_TYPE_MUSIC"         // 音频更新
+	MajorTypeCommon       MajorType = "MAJOR_TYPE_COMMON"        // 一般类型
+	MajorTypeLive         MajorType = "MAJOR_TYPE_LIVE"          // 直播间分享
+	MajorTypeLiveRcmd     MajorType = "MAJOR_TYPE_LIVE_RCMD"     // 直播状态
+	MajorTypeUgcSeason    MajorType = "MAJOR_TYPE_UGC_SEASON"    // 合集更新
+	MajorTypeBlocked      MajorType = "MAJOR_TYPE_BLOCKED"       // 被屏蔽的动态
+	MajorTypeMedialist    MajorType = "MAJOR_TYPE_MEDIALIST"     // 收藏夹
+	MajorTypeApplet       MajorType = "MAJOR_TYPE_APPLET"        // 小程序
+	MajorTypeSubscription MajorType = "MAJOR_TYPE_SUBSCRIPTION"  // 订阅
+	MajorTypeUpowerCommon MajorType = "MAJOR_TYPE_UPOWER_COMMON" // 充电相关
+)
+
+// Dynamic 一条动态，是 DynamicItem 的强类型版本。
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/dynamic/all.md
+type Dynamic struct {
+	IdStr   json.Number    `json:"id_str"`  // 动态id。这个字段，B站返回的数据有时是number，有时是string
+	Type    DynamicType    `json:"type"`    // 动态类型
+	Visible bool           `json:"visible"` // 是否显示。false时为折叠的动态
+	Basic   DynamicBasic   `json:"basic"`   // 动态基本信息
+	Modules DynamicModules `json:"modules"` // 动态信息
+	Orig    *Dynamic       `json:"orig"`    // 被转发的原动态。仅转发动态有此项
+}
+
+type DynamicLikeIcon struct {
+	ActionUrl string      `json:"action_url"` // 点赞动画url
+	EndUrl    string      `json:"end_url"`    // 空串
+	Id        json.Number `json:"id"`         // 点赞图标id
+	StartUrl  string      `json:"start_url"`  // 空串
+}
+
+type DynamicBasic struct {
+	CommentIdStr string          `json:"comment_id_str"` // 评论区id
+	CommentType  int             `json:"comment_type"`   // 评论区类型代码
+	LikeIcon     DynamicLikeIcon `json:"like_icon"`      // 点赞图标
+	RidStr       string          `json:"rid_str"`        // 动态关联的资源id
+	JumpUrl      string          `json:"jump_url"`       // 跳转url
+}
+
+type DynamicModules struct {
+	ModuleAuthor  *DynamicModuleAuthor  `json:"module_author"`  // 作者信息
+	ModuleDynamic *DynamicModuleDynamic `json:"module_dynamic"` // 动态内容信息
+	ModuleStat    *DynamicModuleStat    `json:"module_stat"`    // 动态统计数据。转发的原动态无此项
+	ModuleTag     *DynamicModuleTag     `json:"module_tag"`     // 动态标签。置顶动态才有此项
+}
+
+type DynamicModuleAuthor struct {
+	Face            string         `json:"face"`              // 头像url
+	FaceNft         bool           `json:"face_nft"`          // 是否为NFT头像
+	Following       bool           `json:"following"`         // 是否关注此UP主
+	JumpUrl         string         `json:"jump_url"`          // 跳转链接
+	Label           string         `json:"label"`             // 名称前标签。合集、电视剧、番剧等
+	Mid             json.Number    `json:"mid"`               // UP主mid
+	Name            string         `json:"name"`              // UP主名称
+	OfficialVerify  OfficialVerify `json:"official_verify"`   // UP主认证信息
+	Pendant         Pendant        `json:"pendant"`           // UP主头像框
+	PubAction       string         `json:"pub_action"`        // 更新动作描述。例如：投稿了视频
+	PubLocationText string         `json:"pub_location_text"` // 发布位置
+	PubTime         string         `json:"pub_time"`          // 更新时间的文字描述
+	PubTs           json.Number    `json:"pub_ts"`            // 更新时间。秒级时间戳
+	Type            string         `json:"type"`              // 作者类型。AUTHOR_TYPE_NORMAL：普通更新。AUTHOR_TYPE_PGC：剧集更新。AUTHOR_TYPE_UGC_SEASON：合集更新
+}
+
+// Uid 获取UP主mid
+func (m *DynamicModuleAuthor) Uid() int {
+	return cast.ToInt(m.Mid.String())
+}
+
+// PublishTime 获取更新时间
+func (m *DynamicModuleAuthor) PublishTime() time.Time {
+	return time.Unix(cast.ToInt64(m.PubTs.String()), 0)
+}
+
+type DynamicEmoji struct {
+	IconUrl string `json:"icon_url"` // 表情图片url
+	Size    int    `json:"size"`     // 表情尺寸。1、2
+	Text    string `json:"text"`     // 表情的文字代码
+	Type    int    `json:"type"`     // 表情类型。1、2、3
+}
+
+type DynamicRichTextNode struct {
+	OrigText string        `json:"orig_text"` // 原始文本
+	Text     string        `json:"text"`      // 替换后的文本
+	Type     string        `json:"type"`      // 节点类型。RICH_TEXT_NODE_TYPE_TEXT：文字。RICH_TEXT_NODE_TYPE_AT：@用户。RICH_TEXT_NODE_TYPE_EMOJI：表情。RICH_TEXT_NODE_TYPE_TOPIC：话题。RICH_TEXT_NODE_TYPE_WEB：网页链接。RICH_TEXT_NODE_TYPE_BV：视频链接。RICH_TEXT_NODE_TYPE_LOTTERY：互动抽奖。RICH_TEXT_NODE_TYPE_VOTE：投票。等等
+	JumpUrl  string        `json:"jump_url"`  // 跳转链接
+	Rid      string        `json:"rid"`       // 关联id。@用户时为用户mid
+	Emoji    *DynamicEmoji `json:"emoji"`     // 表情信息。仅表情节点有此项
+}
+
+type DynamicDesc struct {
+	RichTextNodes []DynamicRichTextNode `json:"rich_text_nodes"` // 富文本节点列表
+	Text          string                `json:"text"`            // 动态的文字内容
+}
+
+// RichText 将动态文字内容转换为富文本
+func (d *DynamicDesc) RichText() RichText {
+	result := make(RichText, 0, len(d.RichTextNodes))
+	for _, node := range d.RichTextNodes {
+		text := node.OrigText
+		if len(text) == 0 {
+			text = node.Text
+		}
+		segment := RichTextSegment{Type: RichTextSegmentText, Text: text}
+		jumpUrl := node.JumpUrl
+		if strings.HasPrefix(jumpUrl, "//") {
+			jumpUrl = "https:" + jumpUrl
+		}
+		switch node.Type {
+		case "RICH_TEXT_NODE_TYPE_TEXT":
+		case "RICH_TEXT_NODE_TYPE_AT":
+			segment.Type = RichTextSegmentMention
+			segment.Mid = cast.ToInt(node.Rid)
+			segment.Url = "https://space.bilibili.com/" + node.Rid
+		case "RICH_TEXT_NODE_TYPE_EMOJI":
+			if node.Emoji != nil {
+				segment.Type = RichTextSegmentEmote
+				segment.Url = node.Emoji.IconUrl
+			}
+		case "RICH_TEXT_NODE_TYPE_TOPIC":
+			segment.Type = RichTextSegmentHashtag
+			segment.Url = jumpUrl
+		default:
+			if len(jumpUrl) > 0 {
+				segment.Type = RichTextSegmentLink
+				segment.Title = node.Text
+				segment.Url = jumpUrl
+			}
+		}
+		result = append(result, segment)
+	}
+	return result
+}
+
+type DynamicTopic struct {
+	Id      int    `json:"id"`       // 话题id
+	JumpUrl string `json:"jump_url"` // 跳转url
+	Name    string `json:"name"`     // 话题名称
+}
+
+type DynamicModuleDynamic struct {
+	Additional json.RawMessage `json:"additional"` // 相关内容卡片信息。预约、投票、商品等，结构随类型变化，请自行解析
+	Desc       *DynamicDesc    `json:"desc"`       // 动态文字内容。没有文字内容时为 null
+	Major      DynamicMajor    `json:"-"`          // 动态主体对象。没有时为 nil
+	Topic      *DynamicTopic   `json:"topic"`      // 话题信息。没有时为 null
+}
+
+func (m *DynamicModuleDynamic) UnmarshalJSON(data []byte) error {
+	type moduleDynamic DynamicModuleDynamic
+	var raw struct {
+		moduleDynamic
+		Major json.RawMessage `json:"major"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.WithStack(err)
+	}
+	*m = DynamicModuleDynamic(raw.moduleDynamic)
+	major, err := unmarshalDynamicMajor(raw.Major)
+	if err != nil {
+		return err
+	}
+	m.Major = major
+	return nil
+}
+
+func (m *DynamicModuleDynamic) MarshalJSON() ([]byte, error) {
+	type moduleDynamic DynamicModuleDynamic
+	var major any
+	switch v := m.Major.(type) {
+	case nil:
+	case *MajorUnknown:
+		major = v.Raw
+	default:
+		major = map[string]any{"type": v.MajorType(), majorFields[v.MajorType()]: v}
+	}
+	buf, err := json.Marshal(struct {
+		*moduleDynamic
+		Major any `json:"major"`
+	}{(*moduleDynamic)(m), major})
+	return buf, errors.WithStack(err)
+}
+
+type DynamicStatItem struct {
+	Count     int  `json:"count"`     // 数量
+	Forbidden bool `json:"forbidden"` // 是否禁止
+	Status    bool `json:"status"`    // 当前用户是否点赞。仅点赞有此项
+}
+
+type DynamicModuleStat struct {
+	Comment DynamicStatItem `json:"comment"` // 评论数据
+	Forward DynamicStatItem `json:"forward"` // 转发数据
+	Like    DynamicStatItem `json:"like"`    // 点赞数据
+}
+
+type DynamicModuleTag struct {
+	Text string `json:"text"` // 置顶
+}
+
+// Id 获取动态id
+func (d *Dynamic) Id() string {
+	return d.IdStr.String()
+}
+
+// Author 获取作者信息，没有时返回 nil
+func (d *Dynamic) Author() *DynamicModuleAuthor {
+	return d.Modules.ModuleAuthor
+}
+
+// Desc 获取动态文字内容，没有时返回 nil
+func (d *Dynamic) Desc() *DynamicDesc {
+	if d.Modules.ModuleDynamic == nil {
+		return nil
+	}
+	return d.Modules.ModuleDynamic.Desc
+}
+
+// Major 获取动态主体，没有时返回 nil 。可以通过类型断言或者 type switch 转换为 *MajorArchive 等具体类型
+func (d *Dynamic) Major() DynamicMajor {
+	if d.Modules.ModuleDynamic == nil {
+		return nil
+	}
+	return d.Modules.ModuleDynamic.Major
+}
+
+// Stat 获取动态统计数据，没有时返回 nil
+func (d *Dynamic) Stat() *DynamicModuleStat {
+	return d.Modules.ModuleStat
+}
+
+// Text 获取动态的文字内容。图文动态的文字内容位于 MajorOpus 中，也会一并处理
+func (d *Dynamic) Text() string {
+	if desc := d.Desc(); desc != nil {
+		return desc.Text
+	}
+	if opus, ok := d.Major().(*MajorOpus); ok && opus.Summary != nil {
+		return opus.Summary.Text
+	}
+	return ""
+}
+
+// IsPinned 是否为置顶动态
+func (d *Dynamic) IsPinned() bool {
+	return d.Modules.ModuleTag != nil && d.Modules.ModuleTag.Text == "置顶"
+}
+
+// DynamicMajor 动态主体，具体类型为 *MajorArchive 、 *MajorDraw 、 *MajorOpus 等，
+// 无法识别或者解析失败的类型为 *MajorUnknown
+type DynamicMajor interface {
+	MajorType() MajorType
+}
+
+// majorFields 主体类型与其所在字段名的对应关系
+var majorFields = map[MajorType]string{
+	MajorTypeNone:      "none",
+	MajorTypeOpus:      "opus",
+	MajorTypeArchive:   "archive",
+	MajorTypePgc:       "pgc",
+	MajorTypeCourses:   "courses",
+	MajorTypeDraw:      "draw",
+	MajorTypeArticle:   "article",
+	MajorTypeMusic:     "music",
+	MajorTypeCommon:    "common",
+	MajorTypeLive:      "live",
+	MajorTypeLiveRcmd:  "live_rcmd",
+	MajorTypeUgcSeason: "ugc_season",
+	MajorTypeBlocked:   "blocked",
+}
+
+func unmarshalDynamicMajor(data json.RawMessage) (DynamicMajor, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil //nolint:nilnil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var typ MajorType
+	if err := json.Unmarshal(fields["type"], &typ); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var major DynamicMajor
+	switch typ {
+	case MajorTypeNone:
+		major = &MajorNone{}
+	case MajorTypeOpus:
+		major = &MajorOpus{}
+	case MajorTypeArchive:
+		major = &MajorArchive{}
+	case MajorTypePgc:
+		major = &MajorPgc{}
+	case MajorTypeCourses:
+		major = &MajorCourses{}
+	case MajorTypeDraw:
+		major = &MajorDraw{}
+	case MajorTypeArticle:
+		major = &MajorArticle{}
+	case MajorTypeMusic:
+		major = &MajorMusic{}
+	case MajorTypeCommon:
+		major = &MajorCommon{}
+	case MajorTypeLive:
+		major = &MajorLive{}
+	case MajorTypeLiveRcmd:
+		major = &MajorLiveRcmd{}
+	case MajorTypeUgcSeason:
+		major = &MajorUgcSeason{}
+	case MajorTypeBlocked:
+		major = &MajorBlocked{}
+	default:
+		return &MajorUnknown{Type: typ, Raw: data}, nil
+	}
+	if body, ok := fields[majorFields[typ]]; ok && string(body) != "null" {
+		if err := json.Unmarshal(body, major); err != nil {
+			// B站返回的字段类型时常变化，解析失败时不影响整条动态，按无法识别的类型处理
+			return &MajorUnknown{Type: typ, Raw: data}, nil
+		}
+	}
+	return major, nil
+}
+
+type MajorBadge struct {
+	BgColor string `json:"bg_color"` // 背景颜色
+	Color   string `json:"color"`    // 字体颜色
+	Text    string `json:"text"`     // 角标文案
+}
+
+type MajorStat struct {
+	Danmaku string `json:"danmaku"` // 弹幕数
+	Play    string `json:"play"`    // 播放数
+}
+
+// MajorArchive 视频
+type MajorArchive struct {
+	Aid            string     `json:"aid"`             // 视频avid
+	Badge          MajorBadge `json:"badge"`           // 角标信息
+	Bvid           string     `json:"bvid"`            // 视频bvid
+	Cover          string     `json:"cover"`           // 视频封面
+	Desc           string     `json:"desc"`            // 视频简介
+	DisablePreview int        `json:"disable_preview"` // 0
+	DurationText   string     `json:"duration_text"`   // 视频长度
+	JumpUrl        string     `json:"jump_url"`        // 跳转url
+	Stat           MajorStat  `json:"stat"`            // 统计信息
+	Title          string     `json:"title"`           // 视频标题
+	Type           int        `json:"type"`            // 1
+}
+
+func (*MajorArchive) MajorType() MajorType { return MajorTypeArchive }
+
+// Avid 获取视频avid
+func (m *MajorArchive) Avid() int {
+	aid, _ := strconv.Atoi(m.Aid)
+	return aid
+}
+
+type MajorDrawItem struct {
+	Height int     `json:"height"` // 图片高度
+	Size   float64 `json:"size"`   // 图片大小。单位KB
+	Src    string  `json:"src"`    // 图片url
+	Tags   []any   `json:"tags"`   // 图片标签
+	Width  int     `json:"width"`  // 图片宽度
+}
+
+// MajorDraw 带图动态
+type MajorDraw struct {
+	Id    int             `json:"id"`    // 对应相簿id
+	Items []MajorDrawItem `json:"items"` // 图片信息列表
+}
+
+func (*MajorDraw) MajorType() MajorType { return MajorTypeDraw }
+
+type MajorOpusPic struct {
+	Height  int     `json:"height"`   // 图片高度
+	Size    float64 `json:"size"`     // 图片大小。单位KB
+	Url     string  `json:"url"`      // 图片url
+	Width   int     `json:"width"`    // 图片宽度
+	LiveUrl string  `json:"live_url"` // 实况图片url
+}
+
+// MajorOpus 图文动态
+type MajorOpus struct {
+	FoldAction []string       `json:"fold_action"` // 展开收起
+	JumpUrl    string         `json:"jump_url"`    // 跳转url
+	Pics       []MajorOpusPic `json:"pics"`        // 图片信息
+	Summary    *DynamicDesc   `json:"summary"`     // 动态内容
+	Title      string         `json:"title"`       // 动态标题。没有标题时为空
+}
+
+func (*MajorOpus) MajorType() MajorType { return MajorTypeOpus }
+
+// MajorArticle 专栏
+type MajorArticle struct {
+	Covers  []string `json:"covers"`   // 封面图数组
+	Desc    string   `json:"desc"`     // 文章摘要
+	Id      int      `json:"id"`       // 文章cvid
+	JumpUrl string   `json:"jump_url"` // 文章跳转地址
+	Label   string   `json:"label"`    // 文章阅读量
+	Title   string   `json:"title"`    // 文章标题
+}
+
+func (*MajorArticle) MajorType() MajorType { return MajorTypeArticle }
+
+// MajorLiveRcmd 直播状态
+type MajorLiveRcmd struct {
+	Content     string `json:"content"`      // 直播间内容JSON，可以调用 LivePlayInfo 解析
+	ReserveType int    `json:"reserve_type"` // 0
+}
+
+func (*MajorLiveRcmd) MajorType() MajorType { return MajorTypeLiveRcmd }
+
+type DynamicLivePlayInfo struct {
+	AreaId         int      `json:"area_id"`          // 直播分区id
+	AreaName       string   `json:"area_name"`        // 直播分区名称
+	Cover          string   `json:"cover"`            // 直播间封面
+	LinkUrl        string   `json:"link"`             // 直播间链接
+	LiveId         string   `json:"live_id"`          // 直播id
+	LiveStartTime  int      `json:"live_start_time"`  // 开播时间。秒级时间戳
+	LiveStatus     int      `json:"live_status"`      // 直播状态。0：未开播。1：直播中
+	Online         int      `json:"online"`           // 直播间人气
+	ParentAreaId   int      `json:"parent_area_id"`   // 直播父分区id
+	ParentAreaName string   `json:"parent_area_name"` // 直播父分区名称
+	RoomId         int      `json:"room_id"`          // 直播间id
+	Title          string   `json:"title"`            // 直播间标题
+	Uid            int      `json:"uid"`              // 主播mid
+	WatchedShow    struct { // 看过人数
+		Num       int    `json:"num"`        // 看过人数
+		TextLarge string `json:"text_large"` // 看过人数文案
+		TextSmall string `json:"text_small"` // 看过人数文案
+	} `json:"watched_show"`
+}
+
+// LivePlayInfo 解析直播间信息
+func (m *MajorLiveRcmd) LivePlayInfo() (*DynamicLivePlayInfo, error) {
+	var content struct {
+		LivePlayInfo *DynamicLivePlayInfo `json:"live_play_info"`
+	}
+	if err := json.Unmarshal([]byte(m.Content), &content); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if content.LivePlayInfo == nil {
+		return nil, errors.New("没有直播间信息")
+	}
+	return content.LivePlayInfo, nil
+}
+
+// MajorCommon 一般类型
+type MajorCommon struct {
+	Badge    MajorBadge `json:"badge"`     // 角标信息
+	BizType  int        `json:"biz_type"`  // 业务类型
+	Cover    string     `json:"cover"`     // 左侧图片封面
+	Desc     string     `json:"desc"`      // 右侧描述信息
+	Id       string     `json:"id"`        // 对应的id
+	JumpUrl  string     `json:"jump_url"`  // 跳转地址
+	Label    string     `json:"label"`     // 标签
+	SketchId string     `json:"sketch_id"` // 草图id
+	Style    int        `json:"style"`     // 样式
+	Title    string     `json:"title"`     // 右侧标题
+}
+
+func (*MajorCommon) MajorType() MajorType { return MajorTypeCommon }
+
+// MajorPgc 剧集更新
+type MajorPgc struct {
+	Badge    MajorBadge `json:"badge"`     // 角标信息
+	Cover    string     `json:"cover"`     // 视频封面
+	Epid     int        `json:"epid"`      // 分集epid
+	JumpUrl  string     `json:"jump_url"`  // 跳转url
+	SeasonId int        `json:"season_id"` // 剧集season_id
+	Stat     MajorStat  `json:"stat"`      // 统计信息
+	SubType  int        `json:"sub_type"`  // 剧集类型。1：番剧。2：电影。3：纪录片。4：国创。5：电视剧。6：漫画。7：综艺
+	Title    string     `json:"title"`     // 视频标题
+	Type     int        `json:"type"`      // 2
+}
+
+func (*MajorPgc) MajorType() MajorType { return MajorTypePgc }
+
+// MajorCourses 课程
+type MajorCourses struct {
+	Badge    MajorBadge `json:"badge"`     // 角标信息
+	Cover    string     `json:"cover"`     // 封面图URL
+	Desc     string     `json:"desc"`      // 更新状态描述
+	Id       int        `json:"id"`        // 课程id
+	JumpUrl  string     `json:"jump_url"`  // 跳转url
+	SubTitle string     `json:"sub_title"` // 课程副标题
+	Title    string     `json:"title"`     // 课程标题
+}
+
+func (*MajorCourses) MajorType() MajorType { return MajorTypeCourses }
+
+// MajorMusic 音频更新
+type MajorMusic struct {
+	Cover   string `json:"cover"`    // 音频封面
+	Id      int    `json:"id"`       // 音频auid
+	JumpUrl string `json:"jump_url"` // 跳转url
+	Label   string `json:"label"`    // 音频分类
+	Title   string `json:"title"`    // 音频标题
+}
+
+func (*MajorMusic) MajorType() MajorType { return MajorTypeMusic }
+
+// MajorLive 直播间分享
+type MajorLive struct {
+	Badge       MajorBadge `json:"badge"`        // 角标信息
+	Cover       string     `json:"cover"`        // 直播封面
+	DescFirst   string     `json:"desc_first"`   // 直播主分区名称
+	DescSecond  string     `json:"desc_second"`  // 观看人数
+	Id          int        `json:"id"`           // 直播间id
+	JumpUrl     string     `json:"jump_url"`     // 直播间跳转url
+	LiveState   int        `json:"live_state"`   // 直播状态。0：直播结束。1：正在直播
+	ReserveType int        `json:"reserve_type"` // 0
+	Title       string     `json:"title"`        // 直播间标题
+}
+
+func (*MajorLive) MajorType() MajorType { return MajorTypeLive }
+
+// MajorUgcSeason 合集更新
+type MajorUgcSeason struct {
+	Aid            int        `json:"aid"`             // 视频avid
+	Badge          MajorBadge `json:"badge"`           // 角标信息
+	Cover          string     `json:"cover"`           // 视频封面
+	Desc           string     `json:"desc"`            // 视频简介
+	DisablePreview int        `json:"disable_preview"` // 0
+	DurationText   string     `json:"duration_text"`   // 时长
+	JumpUrl        string     `json:"jump_url"`        // 跳转url
+	Stat           MajorStat  `json:"stat"`            // 统计信息
+	Title          string     `json:"title"`           // 视频标题
+}
+
+func (*MajorUgcSeason) MajorType() MajorType { return MajorTypeUgcSeason }
+
+// MajorNone 动态失效
+type MajorNone struct {
+	Tips string `json:"tips"` // 动态失效显示文案。例如：源动态已被作者删除
+}
+
+func (*MajorNone) MajorType() MajorType { return MajorTypeNone }
+
+// MajorBlocked 被屏蔽的动态，例如需要充电才能查看的动态
+type MajorBlocked struct {
+	BlockedType int    `json:"blocked_type"` // 屏蔽类型
+	HintMessage string `json:"hint_message"` // 提示文案
+}
+
+func (*MajorBlocked) MajorType() MajorType { return MajorTypeBlocked }
+
+// MajorUnknown 暂不支持解析或者解析失败的动态主体，Raw 为原始的 major 对象，请自行解析
+type MajorUnknown struct {
+	Type MajorType       // 动态主体类型
+	Raw  json.RawMessage // 原始数据
+}
+
+func (m *MajorUnknown) MajorType() MajorType { return m.Type }
+
+func (info *DynamicInfo) UnmarshalJSON(data []byte) error {
+	type dynamicInfo DynamicInfo
+	if err := json.Unmarshal(data, (*dynamicInfo)(info)); err != nil {
+		return errors.WithStack(err)
+	}
+	var raw struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.WithStack(err)
+	}
+	// 逐条解析，某条动态的字段类型与定义不符时只把这一条置为 nil ，不影响 Items
+	info.Dynamics = make([]*Dynamic, len(raw.Items))
+	for i, item := range raw.Items {
+		d := &Dynamic{}
+		if err := json.Unmarshal(item, d); err == nil {
+			info.Dynamics[i] = d
+		}
+	}
+	return nil
+}
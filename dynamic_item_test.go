@@ -0,0 +1,112 @@
+package bilibili
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestDynamicInfoUnmarshal(t *testing.T) {
+	data, err := os.ReadFile("testdata/dynamic_space.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Data DynamicInfo `json:"data"`
+	}
+	if err = json.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	info := &resp.Data
+	if len(info.Dynamics) != len(info.Items) || len(info.Dynamics) != 5 {
+		t.Fatalf("unexpected dynamics count: %d, items count: %d", len(info.Dynamics), len(info.Items))
+	}
+
+	archive, ok := info.Dynamics[0].Major().(*MajorArchive)
+	if !ok || archive.Bvid != "BV1gH4y1Y7Xq" || archive.Avid() != 1056250473 || !info.Dynamics[0].IsPinned() {
+		t.Fatalf("unexpected archive: %+v", info.Dynamics[0].Major())
+	}
+	if author := info.Dynamics[0].Author(); author.Uid() != 2 || author.PublishTime().Unix() != 1709280000 {
+		t.Fatalf("unexpected author: %+v", author)
+	}
+
+	opus, ok := info.Dynamics[1].Major().(*MajorOpus)
+	if !ok || len(opus.Pics) != 1 || info.Dynamics[1].Text() != "你好 @小明[doge]" {
+		t.Fatalf("unexpected opus: %+v", info.Dynamics[1].Major())
+	}
+	if r := opus.Summary.RichText(); len(r) != 3 || r[1].Type != RichTextSegmentMention || r[1].Mid != 123 || r[2].Type != RichTextSegmentEmote {
+		t.Fatalf("unexpected rich text: %+v", r)
+	}
+
+	// 字段类型与定义不符时不影响整条动态
+	if unknown, ok := info.Dynamics[2].Major().(*MajorUnknown); !ok || unknown.Type != MajorTypeCommon {
+		t.Fatalf("expected *MajorUnknown, got %+v", info.Dynamics[2].Major())
+	}
+	if info.Dynamics[2].Text() != "分享装扮" {
+		t.Fatalf("unexpected text: %s", info.Dynamics[2].Text())
+	}
+
+	forward := info.Dynamics[3]
+	if forward.Type != DynamicTypeForward || forward.Major() != nil || forward.Orig == nil {
+		t.Fatalf("unexpected forward: %+v", forward)
+	}
+	if none, ok := forward.Orig.Major().(*MajorNone); !ok || none.Tips != "源动态已被作者删除" {
+		t.Fatalf("unexpected orig: %+v", forward.Orig.Major())
+	}
+
+	if unknown, ok := info.Dynamics[4].Major().(*MajorUnknown); !ok || unknown.Type != MajorTypeUpowerCommon {
+		t.Fatalf("expected *MajorUnknown, got %+v", info.Dynamics[4].Major())
+	}
+
+	for _, d := range info.Dynamics {
+		first, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Dynamic
+		if err = json.Unmarshal(first, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Id() != d.Id() || (decoded.Major() == nil) != (d.Major() == nil) {
+			t.Fatalf("round trip mismatch: %s", first)
+		}
+		if d.Major() != nil && decoded.Major().MajorType() != d.Major().MajorType() {
+			t.Fatalf("round trip major type mismatch: %s", first)
+		}
+		second, err := json.Marshal(&decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(first, second) {
+			t.Fatalf("round trip not stable:\n%s\n%s", first, second)
+		}
+	}
+}
+
+func TestDynamicInfoUnmarshalMalformed(t *testing.T) {
+	// 第二条动态主体的字段类型与定义不符，解析为 MajorUnknown ；第三条动态的话题id类型与定义不符，强类型解析失败。
+	// 这些都不影响 Items
+	const data = `{"has_more":false,"offset":"3","items":[
+		{"id_str":"1","type":"DYNAMIC_TYPE_WORD","modules":{"module_dynamic":{"major":null}}},
+		{"id_str":"2","type":"DYNAMIC_TYPE_COMMON_SQUARE","modules":{"module_dynamic":{
+			"major":{"type":"MAJOR_TYPE_COMMON","common":{"id":"10086","sketch_id":1}}}}},
+		{"id_str":"3","type":"DYNAMIC_TYPE_WORD","modules":{"module_dynamic":{"topic":{"id":"abc","name":"话题"},"major":null}}}
+	]}`
+	var info DynamicInfo
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Items) != 3 || len(info.Dynamics) != 3 || info.Offset != "3" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if info.Dynamics[0] == nil || info.Dynamics[0].Id() != "1" || info.Dynamics[2] != nil {
+		t.Fatalf("unexpected dynamics: %+v", info.Dynamics)
+	}
+	if unknown, ok := info.Dynamics[1].Major().(*MajorUnknown); !ok || unknown.Type != MajorTypeCommon {
+		t.Fatalf("expected *MajorUnknown, got %+v", info.Dynamics[1].Major())
+	}
+	if info.Items[2].IdStr.String() != "3" {
+		t.Fatalf("unexpected item: %+v", info.Items[2])
+	}
+}
@@ -80,9 +80,9 @@ func (c *Client) MoveFavourResources(param MoveFavourResourcesParam) error {
 }
 
 type DeleteFavourResourcesParam struct {
-	Resources []int  `json:"resources"`                                    // 目标内容id列表。格式：{内容id}:{内容类型}。类型：2：视频稿件。12：音频。21：视频合集。内容id：。视频稿件：视频稿件avid。音频：音频auid。视频合集：视频合集id
-	MediaId   int    `json:"media_id"`                                     // 目标收藏夹id
-	Platform  string `json:"platform,omitempty" request:"query,omitempty"` // 平台标识。可为web
+	Resources []string `json:"resources"`                                    // 目标内容id列表。格式：{内容id}:{内容类型}。类型：2：视频稿件。12：音频。21：视频合集。内容id：。视频稿件：视频稿件avid。音频：音频auid。视频合集：视频合集id
+	MediaId   int      `json:"media_id"`                                     // 目标收藏夹id
+	Platform  string   `json:"platform,omitempty" request:"query,omitempty"` // 平台标识。可为web
 }
 
 // DeleteFavourResources 批量删除收藏内容
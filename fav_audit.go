@@ -0,0 +1,185 @@
+package bilibili
+
+import (
+	"strconv"
+	"time"
+)
+
+// isFavourInvalid 根据收藏内容的属性位判断是否已失效。属性位的第一位为1时表示已失效，例如9表示UP主自己删除，1表示其他原因删除
+func isFavourInvalid(attr int) bool {
+	return attr&1 != 0
+}
+
+// FavourResourceMeta 收藏内容最后一次有效时的元数据
+type FavourResourceMeta struct {
+	Id        int          `json:"id"`         // 内容id，视频稿件：视频稿件avid，音频：音频auid，视频合集：视频合集id
+	Type      ResourceType `json:"type"`       // 内容类型
+	Bvid      string       `json:"bvid"`       // 视频稿件bvid
+	Title     string       `json:"title"`      // 标题
+	Cover     string       `json:"cover"`      // 封面url
+	UpperMid  int          `json:"upper_mid"`  // UP主mid
+	UpperName string       `json:"upper_name"` // UP主昵称
+	FavTime   int          `json:"fav_time"`   // 收藏时间戳
+	Invalid   bool         `json:"invalid"`    // 是否已失效
+	SeenTime  int64        `json:"seen_time"`  // 最后一次看到该内容有效的时间。时间戳
+}
+
+// Resource 返回对应的 Resource
+func (m *FavourResourceMeta) Resource() Resource {
+	return Resource{Id: m.Id, Type: m.Type}
+}
+
+// FavourAuditState 一个收藏夹的元数据缓存，保存在 Storage 中，键为 favour_audit:{收藏夹mlid}
+type FavourAuditState struct {
+	Resources map[string]*FavourResourceMeta `json:"resources"` // 收藏内容 -> 元数据。键为 {内容id}:{内容类型}
+}
+
+// FavourInvalidItem 一个已失效的收藏内容
+type FavourInvalidItem struct {
+	FavourResourceMeta      // 缓存的元数据。Known 为 false 时只有 Id 、 Type 和 FavTime
+	Known              bool // 是否有该内容失效前的元数据
+}
+
+// FavourAuditReport 一个收藏夹的检查结果
+type FavourAuditReport struct {
+	MediaId      int                 // 收藏夹mlid（完整id）
+	Title        string              // 收藏夹标题
+	Total        int                 // 收藏内容总数
+	Invalid      []FavourInvalidItem // 所有已失效的内容
+	NewlyInvalid []FavourInvalidItem // 上次检查时还有效，这次检查时失效的内容，也包含在 Invalid 中
+}
+
+// FavourAuditor 失效收藏检查器。通过 GetFavourList 扫描收藏夹，根据属性位找出已失效的内容，
+// 同时在 Storage 中缓存每个内容最后一次有效时的标题、封面、UP主和bvid，内容失效后仍然可以知道它原来是什么
+type FavourAuditor struct {
+	client  *Client
+	storage Storage
+}
+
+// NewFavourAuditor 返回一个失效收藏检查器，元数据缓存保存在内存中
+func (c *Client) NewFavourAuditor() *FavourAuditor {
+	return &FavourAuditor{
+		client:  c,
+		storage: &MemoryStorage{data: make(map[string]any)},
+	}
+}
+
+// WithStorage 设置保存元数据缓存的存储，需要在重启后保留缓存时请使用持久化的存储
+func (a *FavourAuditor) WithStorage(storage Storage) *FavourAuditor {
+	a.storage = storage
+	return a
+}
+
+// Audit 检查一个收藏夹，更新元数据缓存，并返回其中已失效的内容。已经不在收藏夹中的内容会从缓存中删除
+func (a *FavourAuditor) Audit(mediaId int) (*FavourAuditReport, error) {
+	key := "favour_audit:" + strconv.Itoa(mediaId)
+	state := a.loadState(key)
+	report := &FavourAuditReport{MediaId: mediaId}
+	now := time.Now().Unix()
+	seen := make(map[string]struct{})
+	for list, err := range a.client.favourListPages(mediaId) {
+		if err != nil {
+			return nil, err
+		}
+		report.Title = list.Info.Title
+		for _, media := range list.Medias {
+			resource := Resource{Id: media.Id, Type: ResourceType(media.Type)}
+			resourceKey := resource.String()
+			seen[resourceKey] = struct{}{}
+			report.Total++
+			meta, known := state.Resources[resourceKey]
+			if !isFavourInvalid(media.Attr) {
+				bvid := media.Bvid
+				if len(bvid) == 0 {
+					bvid = media.BvId
+				}
+				state.Resources[resourceKey] = &FavourResourceMeta{
+					Id:        media.Id,
+					Type:      resource.Type,
+					Bvid:      bvid,
+					Title:     media.Title,
+					Cover:     media.Cover,
+					UpperMid:  media.Upper.Mid,
+					UpperName: media.Upper.Name,
+					FavTime:   media.FavTime,
+					SeenTime:  now,
+				}
+				continue
+			}
+			if !known {
+				meta = &FavourResourceMeta{Id: media.Id, Type: resource.Type, FavTime: media.FavTime}
+			}
+			item := FavourInvalidItem{FavourResourceMeta: *meta, Known: known}
+			item.Invalid = true
+			report.Invalid = append(report.Invalid, item)
+			if known && !meta.Invalid {
+				report.NewlyInvalid = append(report.NewlyInvalid, item)
+			}
+			if known {
+				meta.Invalid = true
+			}
+		}
+	}
+	for resourceKey := range state.Resources {
+		if _, ok := seen[resourceKey]; !ok {
+			delete(state.Resources, resourceKey)
+		}
+	}
+	a.storage.Set(key, state)
+	return report, nil
+}
+
+// AuditAll 检查指定用户创建的所有收藏夹
+func (a *FavourAuditor) AuditAll(mid int) ([]*FavourAuditReport, error) {
+	folders, err := a.client.GetAllFavourFolderInfo(GetAllFavourFolderInfoParam{UpMid: mid})
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*FavourAuditReport, 0, len(folders.List))
+	for _, folder := range folders.List {
+		report, err := a.Audit(folder.Id)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// favourDeleteBatchSize 每次批量删除的最大数量
+const favourDeleteBatchSize = 100
+
+// RemoveInvalid 通过 DeleteFavourResources 从收藏夹中删除检查结果中所有已失效的内容。
+// 删除后下次检查时这些内容的元数据会从缓存中清除，需要保留请在删除前保存检查结果
+func (a *FavourAuditor) RemoveInvalid(report *FavourAuditReport) error {
+	resources := make([]string, 0, len(report.Invalid))
+	for i := range report.Invalid {
+		resources = append(resources, report.Invalid[i].Resource().String())
+	}
+	for len(resources) > 0 {
+		n := min(len(resources), favourDeleteBatchSize)
+		if err := a.client.DeleteFavourResources(DeleteFavourResourcesParam{
+			Resources: resources[:n],
+			MediaId:   report.MediaId,
+			Platform:  "web",
+		}); err != nil {
+			return err
+		}
+		resources = resources[n:]
+	}
+	return nil
+}
+
+// Lookup 在元数据缓存中查找收藏夹中的一个内容，没有缓存时返回 nil
+func (a *FavourAuditor) Lookup(mediaId int, resource Resource) *FavourResourceMeta {
+	state := a.loadState("favour_audit:" + strconv.Itoa(mediaId))
+	return state.Resources[resource.String()]
+}
+
+func (a *FavourAuditor) loadState(key string) *FavourAuditState {
+	state := loadStorageState[FavourAuditState](a.storage, key)
+	if state.Resources == nil {
+		state.Resources = make(map[string]*FavourResourceMeta)
+	}
+	return state
+}
@@ -0,0 +1,69 @@
+package bilibili
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestIsFavourInvalid(t *testing.T) {
+	for attr, expected := range map[int]bool{0: false, 1: true, 2: false, 9: true} {
+		if isFavourInvalid(attr) != expected {
+			t.Fatalf("attr %d, expected invalid: %v", attr, expected)
+		}
+	}
+}
+
+func TestFavourAuditor(t *testing.T) {
+	var pages [][]map[string]any
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/v3/fav/resource/list": func(params url.Values) (any, error) {
+			if params.Get("media_id") != "100" {
+				t.Errorf("unexpected media_id: %s", params.Get("media_id"))
+			}
+			pn, _ := strconv.Atoi(params.Get("pn"))
+			return map[string]any{
+				"info":     map[string]any{"id": 100, "title": "收藏夹"},
+				"medias":   pages[pn-1],
+				"has_more": pn < len(pages),
+			}, nil
+		},
+	})
+	media := func(id, attr int, title string) map[string]any {
+		return map[string]any{"id": id, "type": 2, "attr": attr, "title": title, "upper": map[string]any{"mid": 5, "name": "UP"}}
+	}
+	a := c.NewFavourAuditor()
+
+	// 第一次检查时没有缓存，失效的内容没有元数据
+	pages = [][]map[string]any{{media(1, 0, "a"), media(2, 0, "b"), media(3, 9, "已失效视频")}}
+	report, err := a.Audit(100)
+	if err != nil || report.Total != 3 || report.Title != "收藏夹" || len(report.Invalid) != 1 || len(report.NewlyInvalid) != 0 {
+		t.Fatalf("unexpected report: %+v, %v", report, err)
+	}
+	if item := report.Invalid[0]; item.Known || item.Id != 3 || !item.Invalid {
+		t.Fatalf("unexpected invalid item: %+v", item)
+	}
+
+	// 上次还有效的内容失效后，可以从缓存中得到原来的元数据，不在收藏夹中的内容会从缓存中删除
+	pages = [][]map[string]any{{media(1, 1, "已失效视频")}, {media(4, 0, "d")}}
+	if report, err = a.Audit(100); err != nil || report.Total != 2 || len(report.Invalid) != 1 || len(report.NewlyInvalid) != 1 {
+		t.Fatalf("unexpected report: %+v, %v", report, err)
+	}
+	if item := report.NewlyInvalid[0]; !item.Known || item.Id != 1 || item.Title != "a" || item.UpperName != "UP" || !item.Invalid {
+		t.Fatalf("unexpected newly invalid item: %+v", item)
+	}
+	if a.Lookup(100, Resource{Id: 2, Type: ResourceTypeVideo}) != nil {
+		t.Fatal("expected removed resource to be pruned")
+	}
+	if meta := a.Lookup(100, Resource{Id: 4, Type: ResourceTypeVideo}); meta == nil || meta.Title != "d" || meta.Invalid {
+		t.Fatalf("unexpected meta: %+v", meta)
+	}
+
+	// 再次检查时不会重复报告新失效的内容
+	if report, err = a.Audit(100); err != nil || len(report.Invalid) != 1 || len(report.NewlyInvalid) != 0 || report.Invalid[0].Title != "a" {
+		t.Fatalf("unexpected report: %+v, %v", report, err)
+	}
+	if meta := a.Lookup(100, Resource{Id: 1, Type: ResourceTypeVideo}); meta == nil || meta.Title != "a" || !meta.Invalid {
+		t.Fatalf("unexpected meta: %+v", meta)
+	}
+}
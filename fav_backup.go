@@ -0,0 +1,316 @@
+package bilibili
+
+import (
+	"context"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// FavourBackupVersion 当前收藏夹备份文件的版本号
+const FavourBackupVersion = 1
+
+// FavourBackup 收藏夹备份，可以直接序列化为JSON保存
+type FavourBackup struct {
+	Version    int                  `json:"version"`     // 备份文件版本号，见 FavourBackupVersion
+	Mid        int                  `json:"mid"`         // 导出的用户mid
+	ExportTime int64                `json:"export_time"` // 导出时间。时间戳
+	Folders    []FavourBackupFolder `json:"folders"`     // 收藏夹列表
+}
+
+// FavourBackupFolder 备份中的一个收藏夹
+type FavourBackupFolder struct {
+	Id         int                    `json:"id"`          // 收藏夹mlid（完整id）
+	Title      string                 `json:"title"`       // 收藏夹标题
+	Intro      string                 `json:"intro"`       // 收藏夹简介
+	Cover      string                 `json:"cover"`       // 收藏夹封面图片url
+	Attr       int                    `json:"attr"`        // 属性位。第一位为1时为私密收藏夹，第二位为0时为默认收藏夹
+	Ctime      int                    `json:"ctime"`       // 创建时间戳
+	MediaCount int                    `json:"media_count"` // 收藏夹内容数量
+	Resources  []FavourBackupResource `json:"resources"`   // 收藏夹内容，按收藏时间从新到旧排列
+}
+
+// Private 是否为私密收藏夹
+func (f *FavourBackupFolder) Private() bool {
+	return f.Attr&1 != 0
+}
+
+// Default 是否为默认收藏夹
+func (f *FavourBackupFolder) Default() bool {
+	return f.Attr&2 == 0
+}
+
+// FavourBackupResource 备份中的一个收藏内容
+type FavourBackupResource struct {
+	Id        int          `json:"id"`         // 内容id，视频稿件：视频稿件avid，音频：音频auid，视频合集：视频合集id
+	Type      ResourceType `json:"type"`       // 内容类型
+	Bvid      string       `json:"bvid"`       // 视频稿件bvid
+	Title     string       `json:"title"`      // 标题
+	UpperMid  int          `json:"upper_mid"`  // UP主mid
+	UpperName string       `json:"upper_name"` // UP主昵称
+	Attr      int          `json:"attr"`       // 属性位。第一位为1时表示内容已失效
+	FavTime   int          `json:"fav_time"`   // 收藏时间戳
+}
+
+// Invalid 内容是否已失效，已失效的内容无法再次收藏
+func (r *FavourBackupResource) Invalid() bool {
+	return isFavourInvalid(r.Attr)
+}
+
+// Resource 返回对应的 Resource
+func (r *FavourBackupResource) Resource() Resource {
+	return Resource{Id: r.Id, Type: r.Type}
+}
+
+// ParseFavourBackup 解析JSON格式的收藏夹备份，会检查版本号
+func ParseFavourBackup(data []byte) (*FavourBackup, error) {
+	return parseBackup[FavourBackup](data, FavourBackupVersion, "收藏夹")
+}
+
+// ExportFavourFolders 导出指定用户创建的所有收藏夹以及其中的全部内容，私密收藏夹需要登录对应的账号才能导出
+func (c *Client) ExportFavourFolders(mid int) (*FavourBackup, error) {
+	folders, err := c.GetAllFavourFolderInfo(GetAllFavourFolderInfoParam{UpMid: mid})
+	if err != nil {
+		return nil, err
+	}
+	backup := &FavourBackup{
+		Version:    FavourBackupVersion,
+		Mid:        mid,
+		ExportTime: time.Now().Unix(),
+		Folders:    make([]FavourBackupFolder, 0, len(folders.List)),
+	}
+	for _, f := range folders.List {
+		folder, err := c.exportFavourFolder(f.Id)
+		if err != nil {
+			return nil, err
+		}
+		backup.Folders = append(backup.Folders, *folder)
+	}
+	return backup, nil
+}
+
+// favourListPages 依次返回收藏夹的每一页内容明细，直到最后一页
+func (c *Client) favourListPages(mediaId int) iter.Seq2[*FavourList, error] {
+	return func(yield func(*FavourList, error) bool) {
+		for pn := 1; ; pn++ {
+			list, err := c.GetFavourList(GetFavourListParam{MediaId: mediaId, Ps: 20, Pn: pn, Platform: "web"})
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(list, nil) || !list.HasMore || len(list.Medias) == 0 {
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) exportFavourFolder(mediaId int) (*FavourBackupFolder, error) {
+	folder := &FavourBackupFolder{Id: mediaId}
+	for list, err := range c.favourListPages(mediaId) {
+		if err != nil {
+			return nil, err
+		}
+		folder.Title = list.Info.Title
+		folder.Intro = list.Info.Intro
+		folder.Cover = list.Info.Cover
+		folder.Attr = list.Info.Attr
+		folder.Ctime = list.Info.Ctime
+		folder.MediaCount = list.Info.MediaCount
+		for _, media := range list.Medias {
+			bvid := media.Bvid
+			if len(bvid) == 0 {
+				bvid = media.BvId
+			}
+			folder.Resources = append(folder.Resources, FavourBackupResource{
+				Id:        media.Id,
+				Type:      ResourceType(media.Type),
+				Bvid:      bvid,
+				Title:     media.Title,
+				UpperMid:  media.Upper.Mid,
+				UpperName: media.Upper.Name,
+				Attr:      media.Attr,
+				FavTime:   media.FavTime,
+			})
+		}
+	}
+	return folder, nil
+}
+
+// FavourRestoreState 收藏夹恢复进度，保存在 Storage 中，键为 favour_restore:{备份的mid}:{当前账号的mid}
+type FavourRestoreState struct {
+	Folders  map[string]int `json:"folders"`  // 备份中的收藏夹id -> 恢复到的收藏夹id
+	Restored map[string]int `json:"restored"` // 备份中的收藏夹id -> 已处理的内容数量
+}
+
+// FavourRestoreFailure 恢复失败的一个收藏内容
+type FavourRestoreFailure struct {
+	FolderId int                  // 备份中的收藏夹id
+	Resource FavourBackupResource // 收藏内容
+	Err      error                // 失败原因
+}
+
+// FavourRestoreResult 恢复结果
+type FavourRestoreResult struct {
+	CreatedFolders int                    // 新建的收藏夹数量
+	Added          int                    // 成功收藏的内容数量
+	Skipped        int                    // 已失效或者已经收藏过而跳过的内容数量
+	Failed         []FavourRestoreFailure // 收藏失败的内容
+}
+
+// FavourRestorer 收藏夹恢复器。把 FavourBackup 恢复到当前登录的账号：按备份重新创建收藏夹，
+// 默认收藏夹会恢复到当前账号的默认收藏夹中，然后按收藏时间从旧到新重新收藏每个内容。
+// 每次写操作之间会等待一段时间以免触发风控，进度会在每个内容处理完之后保存，中断后再次调用 Restore 会从中断处继续
+type FavourRestorer struct {
+	client  *Client
+	storage Storage
+	pacer   pacer
+	onError func(err error)
+}
+
+// NewFavourRestorer 返回一个收藏夹恢复器，默认每次写操作之间间隔1秒，进度保存在内存中
+func (c *Client) NewFavourRestorer() *FavourRestorer {
+	return &FavourRestorer{
+		client:  c,
+		storage: &MemoryStorage{data: make(map[string]any)},
+		pacer:   pacer{interval: time.Second},
+	}
+}
+
+// WithStorage 设置保存恢复进度的存储，需要在程序重启后继续恢复时请使用持久化的存储
+func (r *FavourRestorer) WithStorage(storage Storage) *FavourRestorer {
+	r.storage = storage
+	return r
+}
+
+// WithInterval 设置每次写操作之间的间隔
+func (r *FavourRestorer) WithInterval(interval time.Duration) *FavourRestorer {
+	r.pacer.interval = interval
+	return r
+}
+
+// WithErrorHandler 设置单个内容收藏失败时的回调。单个内容失败不会中断恢复，失败的内容也会记录在 FavourRestoreResult.Failed 中
+func (r *FavourRestorer) WithErrorHandler(onError func(err error)) *FavourRestorer {
+	r.onError = onError
+	return r
+}
+
+// Restore 把备份恢复到当前登录的账号，直到全部完成或者 ctx 被取消
+func (r *FavourRestorer) Restore(ctx context.Context, backup *FavourBackup) (*FavourRestoreResult, error) {
+	selfUid := cast.ToInt(r.client.getCookie("DedeUserID"))
+	if selfUid == 0 {
+		return nil, errors.New("B站登录过期")
+	}
+	key := "favour_restore:" + strconv.Itoa(backup.Mid) + ":" + strconv.Itoa(selfUid)
+	state := r.loadState(key)
+	result := &FavourRestoreResult{}
+	var defaultId int
+	for i := range backup.Folders {
+		folder := &backup.Folders[i]
+		folderKey := strconv.Itoa(folder.Id)
+		targetId, ok := state.Folders[folderKey]
+		if !ok {
+			var err error
+			if folder.Default() {
+				if defaultId == 0 {
+					if defaultId, err = r.defaultFolderId(selfUid); err != nil {
+						return result, err
+					}
+				}
+				targetId = defaultId
+			} else {
+				if err = r.pacer.wait(ctx); err != nil {
+					return result, err
+				}
+				if targetId, err = r.createFolder(folder); err != nil {
+					return result, err
+				}
+				result.CreatedFolders++
+			}
+			state.Folders[folderKey] = targetId
+			r.storage.Set(key, state)
+		}
+		if err := r.restoreFolder(ctx, key, state, folder, targetId, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// restoreFolder 按收藏时间从旧到新收藏 folder 中尚未处理的内容
+func (r *FavourRestorer) restoreFolder(ctx context.Context, key string, state *FavourRestoreState, folder *FavourBackupFolder, targetId int, result *FavourRestoreResult) error {
+	folderKey := strconv.Itoa(folder.Id)
+	restored := state.Restored[folderKey]
+	if restored >= len(folder.Resources) {
+		return nil
+	}
+	ids, err := r.client.GetFavourIds(GetFavourIdsParam{MediaId: targetId, Platform: "web"})
+	if err != nil {
+		return err
+	}
+	exists := make(map[Resource]struct{}, len(ids))
+	for _, id := range ids {
+		exists[Resource{Id: id.Id, Type: ResourceType(id.Type)}] = struct{}{}
+	}
+	for ; restored < len(folder.Resources); restored++ {
+		resource := &folder.Resources[len(folder.Resources)-1-restored]
+		if _, ok := exists[resource.Resource()]; ok || resource.Invalid() {
+			result.Skipped++
+		} else {
+			if err = r.pacer.wait(ctx); err != nil {
+				return err
+			}
+			_, err = r.client.FavourVideo(FavourVideoParam{Rid: resource.Id, Type: int(resource.Type), AddMediaIds: []int{targetId}})
+			if err != nil {
+				result.Failed = append(result.Failed, FavourRestoreFailure{FolderId: folder.Id, Resource: *resource, Err: err})
+				if r.onError != nil {
+					r.onError(err)
+				}
+			} else {
+				result.Added++
+			}
+		}
+		state.Restored[folderKey] = restored + 1
+		r.storage.Set(key, state)
+	}
+	return nil
+}
+
+func (r *FavourRestorer) createFolder(folder *FavourBackupFolder) (int, error) {
+	var privacy int
+	if folder.Private() {
+		privacy = 1
+	}
+	info, err := r.client.AddFavourFolder(AddFavourFolderParam{Title: folder.Title, Intro: folder.Intro, Privacy: privacy})
+	if err != nil {
+		return 0, err
+	}
+	return info.Id, nil
+}
+
+func (r *FavourRestorer) defaultFolderId(selfUid int) (int, error) {
+	folders, err := r.client.GetAllFavourFolderInfo(GetAllFavourFolderInfoParam{UpMid: selfUid})
+	if err != nil {
+		return 0, err
+	}
+	for _, folder := range folders.List {
+		if folder.Attr&2 == 0 {
+			return folder.Id, nil
+		}
+	}
+	return 0, errors.New("找不到默认收藏夹")
+}
+
+func (r *FavourRestorer) loadState(key string) *FavourRestoreState {
+	state := loadStorageState[FavourRestoreState](r.storage, key)
+	if state.Folders == nil {
+		state.Folders = make(map[string]int)
+	}
+	if state.Restored == nil {
+		state.Restored = make(map[string]int)
+	}
+	return state
+}
@@ -0,0 +1,173 @@
+package bilibili
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestParseFavourBackup(t *testing.T) {
+	backup := &FavourBackup{
+		Version: FavourBackupVersion,
+		Mid:     1,
+		Folders: []FavourBackupFolder{{
+			Id:   101,
+			Attr: 1,
+			Resources: []FavourBackupResource{
+				{Id: 2, Type: ResourceTypeVideo, Attr: 0},
+				{Id: 3, Type: ResourceTypeAudio, Attr: 9},
+			},
+		}},
+	}
+	buf, err := json.Marshal(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseFavourBackup(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	folder := parsed.Folders[0]
+	if !folder.Private() || !folder.Default() {
+		t.Fatalf("unexpected folder attr: %+v", folder)
+	}
+	if folder.Resources[0].Invalid() || !folder.Resources[1].Invalid() {
+		t.Fatalf("unexpected resources: %+v", folder.Resources)
+	}
+	if folder.Resources[1].Resource().String() != "3:12" {
+		t.Fatalf("unexpected resource: %s", folder.Resources[1].Resource())
+	}
+	if _, err = ParseFavourBackup([]byte(`{"version":99}`)); err == nil {
+		t.Fatal("expected version error")
+	}
+}
+
+func TestFavourRestorer(t *testing.T) {
+	var (
+		added      []string // 依次收藏的 {收藏夹id}:{内容id}
+		created    int
+		idsFailing = true
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/v3/fav/folder/created/list-all": func(params url.Values) (any, error) {
+			if params.Get("up_mid") != "1" {
+				t.Errorf("unexpected up_mid: %s", params.Get("up_mid"))
+			}
+			return map[string]any{"count": 2, "list": []map[string]any{{"id": 600, "attr": 2}, {"id": 500, "attr": 0}}}, nil
+		},
+		"/x/v3/fav/folder/add": func(params url.Values) (any, error) {
+			created++
+			if params.Get("title") != "私密" || params.Get("privacy") != "1" {
+				t.Errorf("unexpected folder: %v", params)
+			}
+			return map[string]any{"id": 700}, nil
+		},
+		"/x/v3/fav/resource/ids": func(params url.Values) (any, error) {
+			switch params.Get("media_id") {
+			case "500":
+				return []map[string]any{{"id": 1, "type": 2}}, nil
+			case "700":
+				if idsFailing {
+					return nil, Error{Code: -500, Message: "服务器错误"}
+				}
+				return []map[string]any{}, nil
+			}
+			t.Errorf("unexpected media_id: %s", params.Get("media_id"))
+			return nil, nil
+		},
+		"/medialist/gateway/coll/resource/deal": func(params url.Values) (any, error) {
+			added = append(added, params.Get("add_media_ids")+":"+params.Get("rid"))
+			if params.Get("rid") == "6" {
+				return nil, Error{Code: 11010, Message: "您访问的内容不存在"}
+			}
+			return map[string]any{"prompt": false}, nil
+		},
+	})
+	backup := &FavourBackup{
+		Version: FavourBackupVersion,
+		Mid:     9,
+		Folders: []FavourBackupFolder{
+			{Id: 101, Attr: 0, Resources: []FavourBackupResource{
+				{Id: 4, Type: ResourceTypeVideo},
+				{Id: 3, Type: ResourceTypeVideo},
+				{Id: 2, Type: ResourceTypeVideo, Attr: 9},
+				{Id: 1, Type: ResourceTypeVideo},
+			}},
+			{Id: 102, Title: "私密", Attr: 3, Resources: []FavourBackupResource{
+				{Id: 6, Type: ResourceTypeVideo},
+				{Id: 5, Type: ResourceTypeVideo},
+			}},
+		},
+	}
+	storage := &MemoryStorage{data: make(map[string]any)}
+	r := c.NewFavourRestorer().WithStorage(storage).WithInterval(0)
+
+	// 默认收藏夹恢复到当前账号的默认收藏夹，跳过已经收藏过的和已失效的内容，按从旧到新的顺序收藏
+	result, err := r.Restore(context.Background(), backup)
+	if err == nil || result.Added != 2 || result.Skipped != 2 || result.CreatedFolders != 1 {
+		t.Fatalf("unexpected result: %+v, %v", result, err)
+	}
+	if !slices.Equal(added, []string{"500:3", "500:4"}) {
+		t.Fatalf("unexpected added: %v", added)
+	}
+
+	// 中断后从中断处继续，不会重新创建收藏夹
+	idsFailing = false
+	added = nil
+	result, err = r.Restore(context.Background(), backup)
+	if err != nil || result.Added != 1 || result.CreatedFolders != 0 || len(result.Failed) != 1 || result.Failed[0].Resource.Id != 6 {
+		t.Fatalf("unexpected resumed result: %+v, %v", result, err)
+	}
+	if !slices.Equal(added, []string{"700:5", "700:6"}) || created != 1 {
+		t.Fatalf("unexpected resumed added: %v, created: %d", added, created)
+	}
+
+	// 全部完成后不会再发出请求
+	added = nil
+	if result, err = r.Restore(context.Background(), backup); err != nil || len(added) != 0 || result.Added != 0 {
+		t.Fatalf("unexpected finished result: %+v, %v", result, err)
+	}
+}
+
+func TestFavourRestorerMultipleAccounts(t *testing.T) {
+	var added []string
+	newClient := func(mid, defaultId int) *Client {
+		c := newStubClient(t, map[string]stubHandler{
+			"/x/v3/fav/folder/created/list-all": func(url.Values) (any, error) {
+				return map[string]any{"count": 1, "list": []map[string]any{{"id": defaultId, "attr": 0}}}, nil
+			},
+			"/x/v3/fav/resource/ids": func(url.Values) (any, error) {
+				return []map[string]any{}, nil
+			},
+			"/medialist/gateway/coll/resource/deal": func(params url.Values) (any, error) {
+				added = append(added, params.Get("add_media_ids")+":"+params.Get("rid"))
+				return map[string]any{"prompt": false}, nil
+			},
+		})
+		c.SetRawCookies("DedeUserID=" + strconv.Itoa(mid) + "; bili_jct=csrf")
+		return c
+	}
+	backup := &FavourBackup{
+		Version: FavourBackupVersion,
+		Mid:     9,
+		Folders: []FavourBackupFolder{{Id: 101, Resources: []FavourBackupResource{
+			{Id: 2, Type: ResourceTypeVideo},
+			{Id: 1, Type: ResourceTypeVideo},
+		}}},
+	}
+	storage := &MemoryStorage{data: make(map[string]any)}
+
+	// 同一份备份恢复到共用 Storage 的两个账号时，进度互不影响
+	for _, account := range []struct{ mid, defaultId int }{{1, 500}, {2, 800}} {
+		r := newClient(account.mid, account.defaultId).NewFavourRestorer().WithStorage(storage).WithInterval(0)
+		if result, err := r.Restore(context.Background(), backup); err != nil || result.Added != 2 {
+			t.Fatalf("unexpected result for %d: %+v, %v", account.mid, result, err)
+		}
+	}
+	if !slices.Equal(added, []string{"500:1", "500:2", "800:1", "800:2"}) {
+		t.Fatalf("unexpected added: %v", added)
+	}
+}
@@ -0,0 +1,360 @@
+package bilibili
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// FollowingBackupVersion 当前关注列表备份文件的版本号
+const FollowingBackupVersion = 1
+
+// FollowingBackup 关注列表备份，可以直接序列化为JSON保存
+type FollowingBackup struct {
+	Version    int                   `json:"version"`     // 备份文件版本号，见 FollowingBackupVersion
+	Mid        int                   `json:"mid"`         // 导出的用户mid
+	ExportTime int64                 `json:"export_time"` // 导出时间。时间戳
+	Tags       []RelationTag         `json:"tags"`        // 自定义的关注分组，不包括默认分组和特别关注
+	Users      []FollowingBackupUser `json:"users"`       // 关注的用户，按关注时间从早到晚排列
+}
+
+// FollowingBackupUser 备份中的一个关注的用户
+type FollowingBackupUser struct {
+	Mid     int    `json:"mid"`     // 用户mid
+	Uname   string `json:"uname"`   // 用户昵称
+	Tags    []int  `json:"tags"`    // 所在的自定义分组id，对应 FollowingBackup.Tags
+	Special bool   `json:"special"` // 是否为特别关注
+	Mtime   int    `json:"mtime"`   // 关注时间。时间戳
+}
+
+// ParseFollowingBackup 解析JSON格式的关注列表备份，会检查版本号
+func ParseFollowingBackup(data []byte) (*FollowingBackup, error) {
+	return parseBackup[FollowingBackup](data, FollowingBackupVersion, "关注列表")
+}
+
+// ExportFollowings 导出当前登录账号的全部关注，包括每个用户所在的分组和是否为特别关注
+func (c *Client) ExportFollowings() (*FollowingBackup, error) {
+	selfUid := cast.ToInt(c.getCookie("DedeUserID"))
+	if selfUid == 0 {
+		return nil, errors.New("B站登录过期")
+	}
+	tags, err := c.GetRelationTags()
+	if err != nil {
+		return nil, err
+	}
+	backup := &FollowingBackup{
+		Version:    FollowingBackupVersion,
+		Mid:        selfUid,
+		ExportTime: time.Now().Unix(),
+	}
+	for _, tag := range tags {
+		if tag.Tagid != RelationTagDefault && tag.Tagid != RelationTagSpecial {
+			backup.Tags = append(backup.Tags, tag)
+		}
+	}
+	const ps = 50
+	for pn := 1; ; pn++ {
+		result, err := c.GetUserFollowings(GetUserFollowingsParam{Vmid: selfUid, Ps: ps, Pn: pn})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range result.List {
+			userTags := make([]int, 0, len(user.Tag))
+			for _, tagid := range user.Tag {
+				if tagid != RelationTagDefault && tagid != RelationTagSpecial {
+					userTags = append(userTags, tagid)
+				}
+			}
+			backup.Users = append(backup.Users, FollowingBackupUser{
+				Mid:     user.Mid,
+				Uname:   user.Uname,
+				Tags:    userTags,
+				Special: user.Special == 1 || slices.Contains(user.Tag, RelationTagSpecial),
+				Mtime:   user.Mtime,
+			})
+		}
+		if len(result.List) < ps {
+			break
+		}
+	}
+	// 接口按关注时间从新到旧返回，恢复时需要从旧到新关注
+	slices.Reverse(backup.Users)
+	return backup, nil
+}
+
+// FollowingFailReason 重新关注失败的原因
+type FollowingFailReason int
+
+const (
+	FollowingFailOther    FollowingFailReason = iota // 其它原因，见 FollowingReplayFailure.Err
+	FollowingFailNotExist                            // 用户不存在或者已注销
+	FollowingFailBlocked                             // 因对方的隐私设置或者拉黑而无法关注
+	FollowingFailBlacked                             // 对方在目标账号的黑名单中
+)
+
+// FollowingReplayFailure 重新关注失败的一个用户
+type FollowingReplayFailure struct {
+	User   FollowingBackupUser // 备份中的用户
+	Reason FollowingFailReason // 失败原因
+	Err    error               // 错误
+}
+
+// FollowingArrangeFailure 关注成功之后设置分组或者特别关注失败的用户
+type FollowingArrangeFailure struct {
+	Mids   []int // 用户mid
+	Tagids []int // 目标账号中的分组id。为空时表示设置特别关注失败
+	Err    error // 错误
+}
+
+// FollowingReplayResult 重新关注的结果
+type FollowingReplayResult struct {
+	CreatedTags   int                       // 新建的分组数量
+	Followed      int                       // 成功关注（包括已经关注过）的用户数量
+	Failed        []FollowingReplayFailure  // 关注失败的用户
+	ArrangeFailed []FollowingArrangeFailure // 设置分组或者特别关注失败的用户，迁移进度不会因此停下，需要自行重试
+}
+
+// FollowingMigrateState 关注迁移进度，保存在 Storage 中，键为 following_migrate:{备份的mid}:{当前账号的mid}
+type FollowingMigrateState struct {
+	Tags map[string]int `json:"tags"` // 备份中的分组id -> 目标账号中的分组id
+	Done int            `json:"done"` // 已处理的用户数量
+}
+
+// FollowingMigrator 关注迁移器。把 FollowingBackup 在当前登录的账号上重放：按备份新建同名分组，
+// 然后按关注时间从旧到新分批关注，再把用户加入对应的分组并设置特别关注。
+// 每次写操作之间会等待一段时间以免触发风控，进度会在每批处理完之后保存，中断后再次调用 Replay 会从中断处继续
+type FollowingMigrator struct {
+	client    *Client
+	storage   Storage
+	pacer     pacer
+	batchSize int
+	onError   func(err error)
+}
+
+// NewFollowingMigrator 返回一个关注迁移器，默认每批20个用户，每次写操作之间间隔2秒，进度保存在内存中
+func (c *Client) NewFollowingMigrator() *FollowingMigrator {
+	return &FollowingMigrator{
+		client:    c,
+		storage:   &MemoryStorage{data: make(map[string]any)},
+		pacer:     pacer{interval: 2 * time.Second},
+		batchSize: 20,
+	}
+}
+
+// WithStorage 设置保存迁移进度的存储，需要在程序重启后继续迁移时请使用持久化的存储
+func (m *FollowingMigrator) WithStorage(storage Storage) *FollowingMigrator {
+	m.storage = storage
+	return m
+}
+
+// WithInterval 设置每次写操作之间的间隔
+func (m *FollowingMigrator) WithInterval(interval time.Duration) *FollowingMigrator {
+	m.pacer.interval = interval
+	return m
+}
+
+// WithBatchSize 设置每批关注的用户数量
+func (m *FollowingMigrator) WithBatchSize(batchSize int) *FollowingMigrator {
+	m.batchSize = max(batchSize, 1)
+	return m
+}
+
+// WithErrorHandler 设置单个用户处理失败时的回调。单个用户失败不会中断迁移，关注失败的用户也会记录在 FollowingReplayResult.Failed 中，设置分组或者特别关注失败的用户会记录在 FollowingReplayResult.ArrangeFailed 中
+func (m *FollowingMigrator) WithErrorHandler(onError func(err error)) *FollowingMigrator {
+	m.onError = onError
+	return m
+}
+
+// Replay 在当前登录的账号上重放备份，直到全部完成、关注数达到上限或者 ctx 被取消
+func (m *FollowingMigrator) Replay(ctx context.Context, backup *FollowingBackup) (*FollowingReplayResult, error) {
+	selfUid := cast.ToInt(m.client.getCookie("DedeUserID"))
+	if selfUid == 0 {
+		return nil, errors.New("B站登录过期")
+	}
+	key := "following_migrate:" + strconv.Itoa(backup.Mid) + ":" + strconv.Itoa(selfUid)
+	state := m.loadState(key)
+	result := &FollowingReplayResult{}
+	if err := m.createTags(ctx, key, state, backup, result); err != nil {
+		return result, err
+	}
+	for state.Done < len(backup.Users) {
+		batch := backup.Users[state.Done:min(state.Done+m.batchSize, len(backup.Users))]
+		followed, err := m.follow(ctx, batch, result)
+		if err != nil {
+			return result, err
+		}
+		if err = m.arrange(ctx, state, followed, result); err != nil {
+			return result, err
+		}
+		state.Done += len(batch)
+		m.storage.Set(key, state)
+	}
+	return result, nil
+}
+
+// createTags 在目标账号中找到或者新建备份中的每个分组
+func (m *FollowingMigrator) createTags(ctx context.Context, key string, state *FollowingMigrateState, backup *FollowingBackup, result *FollowingReplayResult) error {
+	var existing map[string]int
+	for _, tag := range backup.Tags {
+		tagKey := strconv.Itoa(tag.Tagid)
+		if _, ok := state.Tags[tagKey]; ok {
+			continue
+		}
+		if existing == nil {
+			tags, err := m.client.GetRelationTags()
+			if err != nil {
+				return err
+			}
+			existing = make(map[string]int, len(tags))
+			for _, t := range tags {
+				existing[t.Name] = t.Tagid
+			}
+		}
+		if tagid, ok := existing[tag.Name]; ok {
+			state.Tags[tagKey] = tagid
+		} else {
+			if err := m.pacer.wait(ctx); err != nil {
+				return err
+			}
+			created, err := m.client.CreateRelationTag(CreateRelationTagParam{Tag: tag.Name})
+			if err != nil {
+				return err
+			}
+			state.Tags[tagKey] = created.Tagid
+			existing[tag.Name] = created.Tagid
+			result.CreatedTags++
+		}
+		m.storage.Set(key, state)
+	}
+	return nil
+}
+
+// follow 批量关注，失败的用户会再单独关注一次以确定原因，返回关注成功的用户
+func (m *FollowingMigrator) follow(ctx context.Context, batch []FollowingBackupUser, result *FollowingReplayResult) ([]FollowingBackupUser, error) {
+	fids := make([]int, 0, len(batch))
+	for _, user := range batch {
+		fids = append(fids, user.Mid)
+	}
+	if err := m.pacer.wait(ctx); err != nil {
+		return nil, err
+	}
+	batchResult, err := m.client.BatchModifyRelation(BatchModifyRelationParam{Fids: fids, Act: ModifyRelationActFollow, ReSrc: 11})
+	if err != nil {
+		return nil, err
+	}
+	followed := make([]FollowingBackupUser, 0, len(batch))
+	for _, user := range batch {
+		if !slices.Contains(batchResult.FailedFids, user.Mid) {
+			followed = append(followed, user)
+			continue
+		}
+		if err = m.pacer.wait(ctx); err != nil {
+			return nil, err
+		}
+		err = m.client.ModifyRelation(ModifyRelationParam{Fid: user.Mid, Act: ModifyRelationActFollow, ReSrc: 11})
+		reason, ok, fatal := classifyFollowError(err)
+		if fatal {
+			return nil, err
+		}
+		if ok {
+			followed = append(followed, user)
+			continue
+		}
+		result.Failed = append(result.Failed, FollowingReplayFailure{User: user, Reason: reason, Err: err})
+		if m.onError != nil {
+			m.onError(err)
+		}
+	}
+	result.Followed += len(followed)
+	return followed, nil
+}
+
+// classifyFollowError 根据关注接口的错误码判断失败原因。ok 表示实际上已经关注，fatal 表示无法继续关注任何人
+func classifyFollowError(err error) (reason FollowingFailReason, ok, fatal bool) {
+	if err == nil {
+		return FollowingFailOther, true, false
+	}
+	var e Error
+	if !errors.As(err, &e) {
+		return FollowingFailOther, false, false
+	}
+	switch e.Code {
+	case 22014, 22120: // 已经关注用户，无法重复关注
+		return FollowingFailOther, true, false
+	case 22009: // 关注已达上限
+		return FollowingFailOther, false, true
+	case 22013, 40061, -404: // 账号已注销、用户不存在
+		return FollowingFailNotExist, false, false
+	case 22002: // 因对方隐私设置，你还不能关注
+		return FollowingFailBlocked, false, false
+	case 22003: // 请先将对方移出黑名单
+		return FollowingFailBlacked, false, false
+	default:
+		return FollowingFailOther, false, false
+	}
+}
+
+// arrange 把关注成功的用户加入对应的分组并设置特别关注，失败的会记录在 result.ArrangeFailed 中
+func (m *FollowingMigrator) arrange(ctx context.Context, state *FollowingMigrateState, users []FollowingBackupUser, result *FollowingReplayResult) error {
+	// 目标分组相同的用户可以一起设置
+	var keys []string
+	groups := make(map[string][]int)
+	tagids := make(map[string][]int)
+	for _, user := range users {
+		targets := make([]int, 0, len(user.Tags))
+		for _, tagid := range user.Tags {
+			if target, ok := state.Tags[strconv.Itoa(tagid)]; ok {
+				targets = append(targets, target)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		slices.Sort(targets)
+		key := joinInts(targets)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], user.Mid)
+		tagids[key] = targets
+	}
+	for _, key := range keys {
+		if err := m.pacer.wait(ctx); err != nil {
+			return err
+		}
+		if err := m.client.SetRelationTagUsers(SetRelationTagUsersParam{Fids: groups[key], Tagids: tagids[key]}); err != nil {
+			m.arrangeFailed(result, FollowingArrangeFailure{Mids: groups[key], Tagids: tagids[key], Err: err})
+		}
+	}
+	for _, user := range users {
+		if !user.Special {
+			continue
+		}
+		if err := m.pacer.wait(ctx); err != nil {
+			return err
+		}
+		if err := m.client.AddSpecialFollow(SpecialFollowParam{Fid: user.Mid}); err != nil {
+			m.arrangeFailed(result, FollowingArrangeFailure{Mids: []int{user.Mid}, Err: err})
+		}
+	}
+	return nil
+}
+
+func (m *FollowingMigrator) arrangeFailed(result *FollowingReplayResult, failure FollowingArrangeFailure) {
+	result.ArrangeFailed = append(result.ArrangeFailed, failure)
+	if m.onError != nil {
+		m.onError(failure.Err)
+	}
+}
+
+func (m *FollowingMigrator) loadState(key string) *FollowingMigrateState {
+	state := loadStorageState[FollowingMigrateState](m.storage, key)
+	if state.Tags == nil {
+		state.Tags = make(map[string]int)
+	}
+	return state
+}
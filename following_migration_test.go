@@ -0,0 +1,166 @@
+package bilibili
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClassifyFollowError(t *testing.T) {
+	for _, c := range []struct {
+		err    error
+		reason FollowingFailReason
+		ok     bool
+		fatal  bool
+	}{
+		{nil, FollowingFailOther, true, false},
+		{errors.WithStack(Error{Code: 22014}), FollowingFailOther, true, false},
+		{errors.WithStack(Error{Code: 22009}), FollowingFailOther, false, true},
+		{errors.WithStack(Error{Code: 22013}), FollowingFailNotExist, false, false},
+		{errors.WithStack(Error{Code: 22002}), FollowingFailBlocked, false, false},
+		{errors.WithStack(Error{Code: 22003}), FollowingFailBlacked, false, false},
+		{errors.New("timeout"), FollowingFailOther, false, false},
+	} {
+		reason, ok, fatal := classifyFollowError(c.err)
+		if reason != c.reason || ok != c.ok || fatal != c.fatal {
+			t.Fatalf("%v: got %v %v %v", c.err, reason, ok, fatal)
+		}
+	}
+}
+
+func TestParseFollowingBackup(t *testing.T) {
+	backup, err := ParseFollowingBackup([]byte(`{"version":1,"mid":1,"tags":[{"tagid":5,"name":"a"}],"users":[{"mid":2,"tags":[5],"special":true}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Users) != 1 || !backup.Users[0].Special || backup.Tags[0].Name != "a" {
+		t.Fatalf("unexpected backup: %+v", backup)
+	}
+	if _, err = ParseFollowingBackup([]byte(`{"version":2}`)); err == nil {
+		t.Fatal("expected version error")
+	}
+}
+
+func TestFollowingMigrator(t *testing.T) {
+	var (
+		tagCalls    int
+		created     []string
+		batches     []string
+		interrupted bool
+		setTags     []string
+		specials    []string
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/relation/tags": func(url.Values) (any, error) {
+			tagCalls++
+			return []map[string]any{{"tagid": 0, "name": "默认分组"}, {"tagid": 20, "name": "朋友"}}, nil
+		},
+		"/x/relation/tag/create": func(params url.Values) (any, error) {
+			created = append(created, params.Get("tag"))
+			return map[string]any{"tagid": 30}, nil
+		},
+		"/x/relation/batch/modify": func(params url.Values) (any, error) {
+			// 第一次运行时在第二批中断
+			if len(batches) == 1 && !interrupted {
+				interrupted = true
+				return nil, Error{Code: -509, Message: "请求过于频繁，请稍后再试"}
+			}
+			batches = append(batches, params.Get("fids"))
+			return map[string]any{"failed_fids": []int{3, 6}}, nil
+		},
+		"/x/relation/modify": func(params url.Values) (any, error) {
+			if params.Get("fid") == "3" {
+				return nil, Error{Code: 22014, Message: "已经关注用户，无法重复关注"}
+			}
+			return nil, Error{Code: 22002, Message: "因对方隐私设置，你还不能关注"}
+		},
+		"/x/relation/tags/addUsers": func(params url.Values) (any, error) {
+			setTags = append(setTags, params.Get("fids")+"|"+params.Get("tagids"))
+			return nil, nil
+		},
+		"/x/relation/tag/special/add": func(params url.Values) (any, error) {
+			specials = append(specials, params.Get("fid"))
+			return nil, Error{Code: -400, Message: "请求错误"}
+		},
+	})
+	backup := &FollowingBackup{
+		Version: FollowingBackupVersion,
+		Mid:     9,
+		Tags:    []RelationTag{{Tagid: 5, Name: "朋友"}, {Tagid: 6, Name: "同事"}},
+		Users: []FollowingBackupUser{
+			{Mid: 2, Tags: []int{5}},
+			{Mid: 3, Tags: []int{5}},
+			{Mid: 4, Tags: []int{6, 5}, Special: true},
+			{Mid: 5},
+			{Mid: 6, Tags: []int{6}},
+			{Mid: 7, Tags: []int{5}},
+		},
+	}
+	m := c.NewFollowingMigrator().WithInterval(0).WithBatchSize(3)
+
+	// 同名分组直接使用，已经关注过的用户也算作关注成功，目标分组相同的用户一起设置
+	result, err := m.Replay(context.Background(), backup)
+	if err == nil || result.CreatedTags != 1 || result.Followed != 3 || len(result.Failed) != 0 {
+		t.Fatalf("unexpected result: %+v, %v", result, err)
+	}
+	// 设置特别关注失败时记录在结果中
+	if len(result.ArrangeFailed) != 1 || !slices.Equal(result.ArrangeFailed[0].Mids, []int{4}) || result.ArrangeFailed[0].Tagids != nil {
+		t.Fatalf("unexpected arrange failures: %+v", result.ArrangeFailed)
+	}
+	if !slices.Equal(created, []string{"同事"}) || !slices.Equal(batches, []string{"2,3,4"}) {
+		t.Fatalf("unexpected requests: %v, %v", created, batches)
+	}
+	if !slices.Equal(setTags, []string{"2,3|20", "4|20,30"}) || !slices.Equal(specials, []string{"4"}) {
+		t.Fatalf("unexpected arrange: %v, %v", setTags, specials)
+	}
+	if state := m.loadState("following_migrate:9:1"); state.Done != 3 || state.Tags["5"] != 20 || state.Tags["6"] != 30 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// 中断后从 state.Done 继续，使用已经保存的分组映射
+	setTags, specials = nil, nil
+	result, err = m.Replay(context.Background(), backup)
+	if err != nil || result.CreatedTags != 0 || result.Followed != 2 || len(result.Failed) != 1 {
+		t.Fatalf("unexpected resumed result: %+v, %v", result, err)
+	}
+	if failure := result.Failed[0]; failure.User.Mid != 6 || failure.Reason != FollowingFailBlocked {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+	if tagCalls != 1 || len(created) != 1 || !slices.Equal(batches, []string{"2,3,4", "5,6,7"}) {
+		t.Fatalf("unexpected resumed requests: %d, %v, %v", tagCalls, created, batches)
+	}
+	if !slices.Equal(setTags, []string{"7|20"}) || len(specials) != 0 {
+		t.Fatalf("unexpected resumed arrange: %v, %v", setTags, specials)
+	}
+}
+
+func TestFollowingMigratorMultipleAccounts(t *testing.T) {
+	var batches []string
+	newClient := func(mid int) *Client {
+		c := newStubClient(t, map[string]stubHandler{
+			"/x/relation/batch/modify": func(params url.Values) (any, error) {
+				batches = append(batches, params.Get("fids"))
+				return map[string]any{"failed_fids": []int{}}, nil
+			},
+		})
+		c.SetRawCookies("DedeUserID=" + strconv.Itoa(mid) + "; bili_jct=csrf")
+		return c
+	}
+	backup := &FollowingBackup{Version: FollowingBackupVersion, Mid: 9, Users: []FollowingBackupUser{{Mid: 2}, {Mid: 3}}}
+	storage := &MemoryStorage{data: make(map[string]any)}
+
+	// 同一份备份迁移到共用 Storage 的两个账号时，进度互不影响
+	for _, mid := range []int{1, 5} {
+		m := newClient(mid).NewFollowingMigrator().WithStorage(storage).WithInterval(0)
+		if result, err := m.Replay(context.Background(), backup); err != nil || result.Followed != 2 {
+			t.Fatalf("unexpected result for %d: %+v, %v", mid, result, err)
+		}
+	}
+	if !slices.Equal(batches, []string{"2,3", "2,3"}) {
+		t.Fatalf("unexpected batches: %v", batches)
+	}
+}
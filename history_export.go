@@ -0,0 +1,269 @@
+package bilibili
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HistoryRecord 导出的一条历史记录
+type HistoryRecord struct {
+	ViewAt     int    `json:"view_at"`     // 查看时间。时间戳
+	Business   string `json:"business"`    // 业务类型。archive：稿件。pgc：剧集（番剧 / 影视）。live：直播。article-list：文集。article：文章
+	Oid        int    `json:"oid"`         // 目标id，见 HistoryDetail.Oid
+	Bvid       string `json:"bvid"`        // 稿件bvid。仅用于稿件视频
+	Epid       int    `json:"epid"`        // 剧集epid。仅用于剧集
+	Cid        int    `json:"cid"`         // 观看到的对象id，见 HistoryDetail.Cid
+	Page       int    `json:"page"`        // 观看到的视频分P数。仅用于稿件视频
+	Title      string `json:"title"`       // 条目标题
+	ShowTitle  string `json:"show_title"`  // 分P标题。用于稿件视频或剧集
+	AuthorMid  int    `json:"author_mid"`  // UP主mid
+	AuthorName string `json:"author_name"` // UP主昵称
+	TagName    string `json:"tag_name"`    // 子分区名。用于稿件视频和直播
+	Tid        int    `json:"tid"`         // 子分区tid。仅在开启 HistoryExporter.WithZoneResolve 时用于稿件视频
+	Progress   int    `json:"progress"`    // 观看进度。单位为秒。-1表示已看完
+	Duration   int    `json:"duration"`    // 视频总时长。单位为秒
+	Dt         int    `json:"dt"`          // 记录查看的平台代码，见 HistoryDetail.Dt
+}
+
+func newHistoryRecord(item *HistoryList) HistoryRecord {
+	return HistoryRecord{
+		ViewAt:     item.ViewAt,
+		Business:   item.History.Business,
+		Oid:        item.History.Oid,
+		Bvid:       item.History.Bvid,
+		Epid:       item.History.Epid,
+		Cid:        item.History.Cid,
+		Page:       item.History.Page,
+		Title:      item.Title,
+		ShowTitle:  item.ShowTitle,
+		AuthorMid:  item.AuthorMid,
+		AuthorName: item.AuthorName,
+		TagName:    item.TagName,
+		Progress:   item.Progress,
+		Duration:   item.Duration,
+		Dt:         item.History.Dt,
+	}
+}
+
+// key 返回历史记录条目的唯一标识，格式为 {business}:{oid}
+func (r *HistoryRecord) key() string {
+	return r.Business + ":" + strconv.Itoa(r.Oid)
+}
+
+// WatchTime 估算的观看时长，单位为秒。已看完时为视频总时长，直播和专栏为0
+func (r *HistoryRecord) WatchTime() int {
+	if r.Progress < 0 {
+		return r.Duration
+	}
+	if r.Duration > 0 {
+		return min(r.Progress, r.Duration)
+	}
+	return r.Progress
+}
+
+// Completion 观看完成度，范围为0到1。没有视频时长时返回-1
+func (r *HistoryRecord) Completion() float64 {
+	if r.Progress < 0 {
+		return 1
+	}
+	if r.Duration <= 0 {
+		return -1
+	}
+	return min(float64(r.Progress)/float64(r.Duration), 1)
+}
+
+// Device 返回查看平台的名称：手机端、web端、pad端、TV端或其他
+func (r *HistoryRecord) Device() string {
+	switch r.Dt {
+	case 1, 3, 5, 7:
+		return "手机端"
+	case 2:
+		return "web端"
+	case 4, 6:
+		return "pad端"
+	case 33:
+		return "TV端"
+	default:
+		return "其他"
+	}
+}
+
+var historyCSVHeader = []string{
+	"view_at", "business", "oid", "bvid", "epid", "cid", "page", "title", "show_title",
+	"author_mid", "author_name", "tag_name", "tid", "progress", "duration", "dt",
+}
+
+func (r *HistoryRecord) csvRecord() []string {
+	return []string{
+		strconv.Itoa(r.ViewAt), r.Business, strconv.Itoa(r.Oid), r.Bvid, strconv.Itoa(r.Epid), strconv.Itoa(r.Cid),
+		strconv.Itoa(r.Page), r.Title, r.ShowTitle, strconv.Itoa(r.AuthorMid), r.AuthorName, r.TagName,
+		strconv.Itoa(r.Tid), strconv.Itoa(r.Progress), strconv.Itoa(r.Duration), strconv.Itoa(r.Dt),
+	}
+}
+
+// WriteHistoryJSONL 把历史记录以JSON Lines格式写入 w ，每行一条，可以直接追加到之前导出的文件末尾
+func WriteHistoryJSONL(w io.Writer, records []HistoryRecord) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for i := range records {
+		if err := encoder.Encode(&records[i]); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// WriteHistoryCSV 把历史记录以CSV格式写入 w 。header 为 true 时会先写入标题行，追加到之前导出的文件末尾时应为 false
+func WriteHistoryCSV(w io.Writer, records []HistoryRecord, header bool) error {
+	writer := csv.NewWriter(w)
+	if header {
+		if err := writer.Write(historyCSVHeader); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	for i := range records {
+		if err := writer.Write(records[i].csvRecord()); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	writer.Flush()
+	return errors.WithStack(writer.Error())
+}
+
+// HistoryExportState 历史记录导出进度，保存在 Storage 中，键为 history_export
+type HistoryExportState struct {
+	ViewAt int      `json:"view_at"` // 已导出的最新查看时间。时间戳
+	Keys   []string `json:"keys"`    // 查看时间等于 ViewAt 的已导出条目。格式为 {business}:{oid}
+}
+
+// HistoryExporter 历史记录导出器。通过 GetHistory 的游标遍历全部类型的历史记录，
+// 并与 Storage 中保存的进度对比，每次只导出上次导出之后新增的记录
+type HistoryExporter struct {
+	client      *Client
+	storage     Storage
+	zoneResolve bool
+	pacer       pacer
+	tids        map[int]int
+}
+
+// NewHistoryExporter 返回一个历史记录导出器，进度保存在内存中，获取分区tid时每次请求之间默认间隔500毫秒
+func (c *Client) NewHistoryExporter() *HistoryExporter {
+	return &HistoryExporter{
+		client:  c,
+		storage: &MemoryStorage{data: make(map[string]any)},
+		pacer:   pacer{interval: 500 * time.Millisecond},
+		tids:    make(map[int]int),
+	}
+}
+
+// WithStorage 设置保存导出进度的存储，需要增量导出时请使用持久化的存储
+func (e *HistoryExporter) WithStorage(storage Storage) *HistoryExporter {
+	e.storage = storage
+	return e
+}
+
+// WithZoneResolve 设置是否通过 GetVideoInfo 获取稿件视频的分区tid。
+// 开启后每个没有获取过的稿件会多一次请求，请求之间按 WithInterval 设置的间隔等待，首次导出大量记录时会很慢
+func (e *HistoryExporter) WithZoneResolve(zoneResolve bool) *HistoryExporter {
+	e.zoneResolve = zoneResolve
+	return e
+}
+
+// WithInterval 设置获取分区tid时每次请求之间的间隔
+func (e *HistoryExporter) WithInterval(interval time.Duration) *HistoryExporter {
+	e.pacer.interval = interval
+	return e
+}
+
+const historyExportKey = "history_export"
+
+// Export 获取上次导出之后新增的历史记录，按查看时间从旧到新传给 write 。
+// write 返回 nil 之后才会保存进度，因此写入失败时下次导出仍然会包含这些记录。返回导出的记录数
+func (e *HistoryExporter) Export(write func(records []HistoryRecord) error) (int, error) {
+	state := e.loadState()
+	records, err := e.fetch(state)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+	slices.Reverse(records)
+	if err = write(records); err != nil {
+		return 0, err
+	}
+	newState := &HistoryExportState{ViewAt: records[len(records)-1].ViewAt}
+	if newState.ViewAt == state.ViewAt {
+		newState.Keys = state.Keys
+	}
+	for i := range records {
+		if records[i].ViewAt == newState.ViewAt {
+			newState.Keys = append(newState.Keys, records[i].key())
+		}
+	}
+	e.storage.Set(historyExportKey, newState)
+	return len(records), nil
+}
+
+// fetch 从新到旧获取查看时间不早于 state.ViewAt 且没有导出过的历史记录
+func (e *HistoryExporter) fetch(state *HistoryExportState) ([]HistoryRecord, error) {
+	var (
+		records []HistoryRecord
+		param   = GetHistoryParam{Type: "all", Ps: 30}
+	)
+	for {
+		info, err := e.client.GetHistory(param)
+		if err != nil {
+			return nil, err
+		}
+		for i := range info.List {
+			record := newHistoryRecord(&info.List[i])
+			if record.ViewAt < state.ViewAt {
+				return records, nil
+			}
+			if record.ViewAt == state.ViewAt && slices.Contains(state.Keys, record.key()) {
+				continue
+			}
+			if e.zoneResolve && record.Business == "archive" {
+				if record.Tid, err = e.resolveTid(record.Oid); err != nil {
+					return nil, err
+				}
+			}
+			records = append(records, record)
+		}
+		if len(info.List) == 0 || info.Cursor.Max == 0 {
+			return records, nil
+		}
+		param.Max, param.ViewAt, param.Business = info.Cursor.Max, info.Cursor.ViewAt, info.Cursor.Business
+	}
+}
+
+// resolveTid 获取稿件的分区tid。稿件已失效等B站返回错误码的情况返回0，网络错误等其它错误会返回错误，下次导出时重试
+func (e *HistoryExporter) resolveTid(aid int) (int, error) {
+	if tid, ok := e.tids[aid]; ok {
+		return tid, nil
+	}
+	if err := e.pacer.wait(context.Background()); err != nil {
+		return 0, err
+	}
+	var tid int
+	info, err := e.client.GetVideoInfo(VideoParam{Aid: aid})
+	if err == nil {
+		tid = info.Tid
+	} else if apiErr := (Error{}); !errors.As(err, &apiErr) {
+		return 0, err
+	}
+	e.tids[aid] = tid
+	return tid, nil
+}
+
+func (e *HistoryExporter) loadState() *HistoryExportState {
+	return loadStorageState[HistoryExportState](e.storage, historyExportKey)
+}
@@ -0,0 +1,100 @@
+package bilibili
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestHistoryExporter(t *testing.T) {
+	var (
+		history  []map[string]any // 从新到旧排列
+		pages    int
+		viewHits = make(map[string]int)
+	)
+	item := func(oid, viewAt int) map[string]any {
+		return map[string]any{"view_at": viewAt, "title": "视频" + strconv.Itoa(oid), "history": map[string]any{"oid": oid, "business": "archive"}}
+	}
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/web-interface/history/cursor": func(params url.Values) (any, error) {
+			// 每页2条，游标的 max 为下一页的起始位置
+			pages++
+			start, _ := strconv.Atoi(params.Get("max"))
+			end := min(start+2, len(history))
+			next := 0
+			if end < len(history) {
+				next = end
+			}
+			return map[string]any{"cursor": map[string]any{"max": next}, "list": history[start:end]}, nil
+		},
+		"/x/web-interface/view": func(params url.Values) (any, error) {
+			aid := params.Get("aid")
+			viewHits[aid]++
+			if aid == "5" {
+				return nil, Error{Code: 62002, Message: "稿件不可见"}
+			}
+			tid, _ := strconv.Atoi(aid)
+			return map[string]any{"aid": tid, "tid": tid * 10}, nil
+		},
+	})
+	exporter := c.NewHistoryExporter().WithZoneResolve(true).WithInterval(0)
+	var exported []HistoryRecord
+	write := func(records []HistoryRecord) error {
+		exported = append(exported, records...)
+		return nil
+	}
+	oids := func(records []HistoryRecord) []int {
+		result := make([]int, 0, len(records))
+		for _, record := range records {
+			result = append(result, record.Oid)
+		}
+		return result
+	}
+
+	history = []map[string]any{item(1, 100), item(2, 100), item(3, 90), item(4, 80)}
+	if n, err := exporter.Export(write); err != nil || n != 4 {
+		t.Fatalf("unexpected first export: %d, %v", n, err)
+	}
+	if got := oids(exported); len(got) != 4 || got[0] != 4 || got[1] != 3 || exported[0].Tid != 40 {
+		t.Fatalf("records should be exported from old to new: %v", exported)
+	}
+	if state := exporter.loadState(); state.ViewAt != 100 || len(state.Keys) != 2 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// 新增的记录与上次最新的记录查看时间相同，只导出没有导出过的，遇到更早的记录时停止翻页
+	history = append([]map[string]any{item(6, 110), item(5, 100)}, history...)
+	exported, pages = nil, 0
+	writeErr := errors.New("磁盘已满")
+	if n, err := exporter.Export(func([]HistoryRecord) error { return writeErr }); !errors.Is(err, writeErr) || n != 0 {
+		t.Fatalf("expected write error: %d, %v", n, err)
+	}
+	if pages != 3 {
+		t.Fatalf("expected to stop at older records, got %d pages", pages)
+	}
+	if state := exporter.loadState(); state.ViewAt != 100 || len(state.Keys) != 2 {
+		t.Fatalf("state should not be saved after write failure: %+v", state)
+	}
+
+	// 写入失败后重新导出同样的记录，已获取的分区不再重复请求
+	if n, err := exporter.Export(write); err != nil || n != 2 {
+		t.Fatalf("unexpected retry: %d, %v", n, err)
+	}
+	if got := oids(exported); len(got) != 2 || got[0] != 5 || got[1] != 6 {
+		t.Fatalf("unexpected records: %v", got)
+	}
+	if exported[0].Tid != 0 || exported[1].Tid != 60 || viewHits["5"] != 1 || viewHits["6"] != 1 {
+		t.Fatalf("unexpected tid resolve: %v, %v", exported, viewHits)
+	}
+	if state := exporter.loadState(); state.ViewAt != 110 || len(state.Keys) != 1 || state.Keys[0] != "archive:6" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// 没有新记录时不调用 write
+	exported = nil
+	if n, err := exporter.Export(write); err != nil || n != 0 || exported != nil {
+		t.Fatalf("unexpected empty export: %d, %v, %v", n, err, exported)
+	}
+}
@@ -0,0 +1,144 @@
+package bilibili
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryGroupStat 一组历史记录的统计
+type HistoryGroupStat struct {
+	Key        string  // 分组的键。UP主为mid，分区为主分区或子分区名称，日期为 2006-01-02 格式，平台为平台名称
+	Name       string  // 分组的名称。UP主为昵称，其余与 Key 相同
+	Count      int     // 记录数
+	WatchTime  int     // 估算的观看总时长。单位为秒，见 HistoryRecord.WatchTime
+	Completion float64 // 平均观看完成度，只统计有视频时长的记录。没有这样的记录时为-1
+}
+
+// HistoryStats 历史记录统计
+type HistoryStats struct {
+	Count      int                // 记录总数
+	WatchTime  int                // 估算的观看总时长。单位为秒
+	Completion float64            // 平均观看完成度。没有有视频时长的记录时为-1
+	Ups        []HistoryGroupStat // 按UP主统计，按观看时长从多到少排列
+	Zones      []HistoryGroupStat // 按主分区统计，只包括能通过 Tid 找到主分区的记录，按观看时长从多到少排列
+	SubZones   []HistoryGroupStat // 其余记录按子分区名统计，按观看时长从多到少排列
+	Days       []HistoryGroupStat // 按日期统计，按日期从早到晚排列
+	Devices    []HistoryGroupStat // 按查看平台统计，按记录数从多到少排列
+}
+
+type historyGroup struct {
+	stat            HistoryGroupStat
+	completionSum   float64
+	completionCount int
+}
+
+func (g *historyGroup) add(record *HistoryRecord) {
+	g.stat.Count++
+	g.stat.WatchTime += record.WatchTime()
+	if completion := record.Completion(); completion >= 0 {
+		g.completionSum += completion
+		g.completionCount++
+	}
+}
+
+func (g *historyGroup) result() HistoryGroupStat {
+	g.stat.Completion = -1
+	if g.completionCount > 0 {
+		g.stat.Completion = g.completionSum / float64(g.completionCount)
+	}
+	return g.stat
+}
+
+type historyGroups map[string]*historyGroup
+
+func (groups historyGroups) add(key, name string, record *HistoryRecord) {
+	g, ok := groups[key]
+	if !ok {
+		g = &historyGroup{stat: HistoryGroupStat{Key: key, Name: name}}
+		groups[key] = g
+	}
+	g.add(record)
+}
+
+func (groups historyGroups) result(compare func(a, b HistoryGroupStat) int) []HistoryGroupStat {
+	stats := make([]HistoryGroupStat, 0, len(groups))
+	for _, g := range groups {
+		stats = append(stats, g.result())
+	}
+	slices.SortFunc(stats, func(a, b HistoryGroupStat) int {
+		return cmp.Or(compare(a, b), cmp.Compare(a.Key, b.Key))
+	})
+	return stats
+}
+
+// historyZoneName 通过 GetZoneInfoByTid 查找历史记录所属的主分区名称，没有 Tid 或找不到时返回空字符串
+func historyZoneName(record *HistoryRecord, zones map[int]string) string {
+	if record.Tid == 0 {
+		return ""
+	}
+	if name, ok := zones[record.Tid]; ok {
+		return name
+	}
+	var name string
+	if zone, err := GetZoneInfoByTid(record.Tid); err == nil {
+		if master, err := GetZoneInfoByTid(zone.MasterTid); err == nil {
+			name = strings.TrimSuffix(master.Name, "(主分区)")
+		}
+	}
+	zones[record.Tid] = name
+	return name
+}
+
+// AnalyzeHistory 统计历史记录，日期按 loc 时区划分，为 nil 时使用本地时区。
+// 主分区统计需要导出时开启 HistoryExporter.WithZoneResolve ，其余记录按子分区名计入 SubZones ，
+// 两者不会混在一起。没有子分区名的记录不计入分区统计
+func AnalyzeHistory(records []HistoryRecord, loc *time.Location) *HistoryStats {
+	if loc == nil {
+		loc = time.Local
+	}
+	var (
+		total   historyGroup
+		ups     = make(historyGroups)
+		zones   = make(historyGroups)
+		tags    = make(historyGroups)
+		days    = make(historyGroups)
+		devices = make(historyGroups)
+		names   = make(map[int]string)
+	)
+	for i := range records {
+		record := &records[i]
+		total.add(record)
+		if record.AuthorMid > 0 {
+			ups.add(strconv.Itoa(record.AuthorMid), record.AuthorName, record)
+		}
+		if zone := historyZoneName(record, names); len(zone) > 0 {
+			zones.add(zone, zone, record)
+		} else if len(record.TagName) > 0 {
+			tags.add(record.TagName, record.TagName, record)
+		}
+		day := time.Unix(int64(record.ViewAt), 0).In(loc).Format(time.DateOnly)
+		days.add(day, day, record)
+		device := record.Device()
+		devices.add(device, device, record)
+	}
+	byWatchTime := func(a, b HistoryGroupStat) int {
+		return cmp.Compare(b.WatchTime, a.WatchTime)
+	}
+	totalStat := total.result()
+	return &HistoryStats{
+		Count:      totalStat.Count,
+		WatchTime:  totalStat.WatchTime,
+		Completion: totalStat.Completion,
+		Ups:        ups.result(byWatchTime),
+		Zones:      zones.result(byWatchTime),
+		SubZones:   tags.result(byWatchTime),
+		// 日期的键为 2006-01-02 格式，按键排序即为按日期排序
+		Days: days.result(func(a, b HistoryGroupStat) int { return 0 }),
+		Devices: devices.result(func(a, b HistoryGroupStat) int {
+			return cmp.Compare(b.Count, a.Count)
+		}),
+	}
+}
@@ -0,0 +1,55 @@
+package bilibili
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeHistory(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	day1 := int(time.Date(2024, 1, 1, 12, 0, 0, 0, loc).Unix())
+	day2 := int(time.Date(2024, 1, 2, 12, 0, 0, 0, loc).Unix())
+	records := []HistoryRecord{
+		{ViewAt: day1, Business: "archive", Oid: 1, AuthorMid: 10, AuthorName: "a", TagName: "单机游戏", Progress: 30, Duration: 60, Dt: 2},
+		{ViewAt: day1 + 60, Business: "archive", Oid: 2, AuthorMid: 10, AuthorName: "a", Tid: 24, Progress: -1, Duration: 100, Dt: 1},
+		{ViewAt: day2, Business: "archive", Oid: 3, AuthorMid: 20, AuthorName: "b", TagName: "单机游戏", Progress: 10, Duration: 200, Dt: 3},
+		{ViewAt: day2 + 60, Business: "live", Oid: 4, AuthorMid: 20, AuthorName: "b", TagName: "虚拟主播", Dt: 33},
+	}
+	stats := AnalyzeHistory(records, loc)
+	if stats.Count != 4 || stats.WatchTime != 140 {
+		t.Fatalf("unexpected total: %+v", stats)
+	}
+	if expected := (0.5 + 1 + 0.05) / 3; stats.Completion < expected-1e-9 || stats.Completion > expected+1e-9 {
+		t.Fatalf("unexpected completion: %f", stats.Completion)
+	}
+	if len(stats.Ups) != 2 || stats.Ups[0].Key != "10" || stats.Ups[0].WatchTime != 130 || stats.Ups[1].Name != "b" {
+		t.Fatalf("unexpected ups: %+v", stats.Ups)
+	}
+	// 主分区和子分区分开统计
+	if len(stats.Zones) != 1 || stats.Zones[0].Key != "动画" || stats.Zones[0].Count != 1 {
+		t.Fatalf("unexpected zones: %+v", stats.Zones)
+	}
+	if len(stats.SubZones) != 2 || stats.SubZones[0].Key != "单机游戏" || stats.SubZones[0].Count != 2 || stats.SubZones[1].Key != "虚拟主播" {
+		t.Fatalf("unexpected sub zones: %+v", stats.SubZones)
+	}
+	if len(stats.Days) != 2 || stats.Days[0].Key != "2024-01-01" || stats.Days[1].Count != 2 {
+		t.Fatalf("unexpected days: %+v", stats.Days)
+	}
+	if len(stats.Devices) != 3 || stats.Devices[0].Key != "手机端" || stats.Devices[0].Count != 2 {
+		t.Fatalf("unexpected devices: %+v", stats.Devices)
+	}
+}
+
+func TestWriteHistoryCSV(t *testing.T) {
+	var buf bytes.Buffer
+	records := []HistoryRecord{{ViewAt: 1, Business: "archive", Oid: 2, Title: "a,b"}}
+	if err := WriteHistoryCSV(&buf, records, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], `1,archive,2,,0,0,0,"a,b",`) {
+		t.Fatalf("unexpected csv: %s", buf.String())
+	}
+}
@@ -1,6 +1,8 @@
 package bilibili
 
 import (
+	"encoding/json"
+	"maps"
 	"strconv"
 	"time"
 
@@ -276,3 +278,107 @@ func (c *Client) GetHomePageLiveVersion(param GetHomePageLiveVersionParam) (*Hom
 	)
 	return execute[*HomePageLiveVersion](c, method, url, param)
 }
+
+// liveBatchSize 批量查询直播间时单次请求的最大数量，超过时会自动分批请求
+const liveBatchSize = 50
+
+type GetLiveStatusByUidsParam struct {
+	Uids []int `json:"uids" request:"-"` // 主播mid列表，数量不限，会自动分批请求
+}
+
+type LiveStatusInfo struct {
+	Title            string `json:"title"`               // 直播间标题
+	RoomId           int    `json:"room_id"`             // 直播间长号
+	Uid              int    `json:"uid"`                 // 主播mid
+	Online           int    `json:"online"`              // 观看人数
+	LiveTime         int    `json:"live_time"`           // 开播时间。秒级时间戳，未开播时为0
+	LiveStatus       int    `json:"live_status"`         // 直播状态。0：未开播。1：直播中。2：轮播中
+	ShortId          int    `json:"short_id"`            // 直播间短号。为0是无短号
+	Area             int    `json:"area"`                // 旧版分区id
+	AreaName         string `json:"area_name"`           // 旧版分区名称
+	AreaV2Id         int    `json:"area_v2_id"`          // 分区id
+	AreaV2Name       string `json:"area_v2_name"`        // 分区名称
+	AreaV2ParentId   int    `json:"area_v2_parent_id"`   // 父分区id
+	AreaV2ParentName string `json:"area_v2_parent_name"` // 父分区名称
+	Uname            string `json:"uname"`               // 主播昵称
+	Face             string `json:"face"`                // 主播头像url
+	TagName          string `json:"tag_name"`            // 直播间标签
+	Tags             string `json:"tags"`                // 直播间自定义标签
+	CoverFromUser    string `json:"cover_from_user"`     // 直播间封面url
+	Keyframe         string `json:"keyframe"`            // 关键帧url
+	LockTill         string `json:"lock_till"`           // 直播间封禁信息
+	HiddenTill       string `json:"hidden_till"`         // 直播间隐藏信息
+	BroadcastType    int    `json:"broadcast_type"`      // 直播类型。0：普通直播。1：手机直播
+}
+
+// GetLiveStatusByUids 批量获取主播的直播状态，返回 主播mid -> 直播状态 的映射。没有直播间的主播不会出现在结果中
+func (c *Client) GetLiveStatusByUids(param GetLiveStatusByUidsParam) (map[int]*LiveStatusInfo, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.live.bilibili.com/room/v1/Room/get_status_info_by_uids"
+	)
+	return batchGetLive(param.Uids, func(uids []int) (map[int]*LiveStatusInfo, error) {
+		data, err := execute[json.RawMessage](c, method, url, param, fillParams("uids[]", uids))
+		if err != nil {
+			return nil, err
+		}
+		return decodeIntKeyMap[*LiveStatusInfo](data)
+	})
+}
+
+type GetLiveRoomBaseInfoParam struct {
+	ReqBiz  string `json:"req_biz" request:"query,default=web_room_componet"` // web_room_componet
+	RoomIds []int  `json:"room_ids" request:"-"`                              // 直播间号列表，数量不限，会自动分批请求
+}
+
+type LiveRoomBaseInfo struct {
+	RoomId         int    `json:"room_id"`          // 直播间长号
+	Uid            int    `json:"uid"`              // 主播mid
+	AreaId         int    `json:"area_id"`          // 分区id
+	LiveStatus     int    `json:"live_status"`      // 直播状态。0：未开播。1：直播中。2：轮播中
+	LiveUrl        string `json:"live_url"`         // 直播间url
+	ParentAreaId   int    `json:"parent_area_id"`   // 父分区id
+	Title          string `json:"title"`            // 直播间标题
+	ParentAreaName string `json:"parent_area_name"` // 父分区名称
+	AreaName       string `json:"area_name"`        // 分区名称
+	LiveTime       string `json:"live_time"`        // 开播时间。YYYY-MM-DD HH:mm:ss ，未开播时为 0000-00-00 00:00:00
+	Description    string `json:"description"`      // 直播间简介
+	Tags           string `json:"tags"`             // 直播间标签。','分隔
+	Attention      int    `json:"attention"`        // 关注数量
+	Online         int    `json:"online"`           // 观看人数
+	ShortId        int    `json:"short_id"`         // 直播间短号。为0是无短号
+	Uname          string `json:"uname"`            // 主播昵称
+	Cover          string `json:"cover"`            // 直播间封面url
+	Background     string `json:"background"`       // 直播间背景图url
+	LiveIdStr      string `json:"live_id_str"`      // 本场直播的id
+}
+
+// GetLiveRoomBaseInfo 批量获取直播间的基本信息，返回 直播间长号 -> 直播间信息 的映射
+func (c *Client) GetLiveRoomBaseInfo(param GetLiveRoomBaseInfoParam) (map[int]*LiveRoomBaseInfo, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.live.bilibili.com/xlive/web-room/v1/index/getRoomBaseInfo"
+	)
+	return batchGetLive(param.RoomIds, func(roomIds []int) (map[int]*LiveRoomBaseInfo, error) {
+		result, err := execute[*struct {
+			ByRoomIds json.RawMessage `json:"by_room_ids"`
+		}](c, method, url, param, fillParams("room_ids", roomIds))
+		if err != nil {
+			return nil, err
+		}
+		return decodeIntKeyMap[*LiveRoomBaseInfo](result.ByRoomIds)
+	})
+}
+
+// batchGetLive 按 liveBatchSize 分批请求，并合并所有结果
+func batchGetLive[T any](ids []int, f func([]int) (map[int]T, error)) (map[int]T, error) {
+	result := make(map[int]T, len(ids))
+	for start := 0; start < len(ids); start += liveBatchSize {
+		m, err := f(ids[start:min(start+liveBatchSize, len(ids))])
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(result, m)
+	}
+	return result, nil
+}
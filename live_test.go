@@ -0,0 +1,101 @@
+package bilibili
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestGetLiveStatusByUids(t *testing.T) {
+	var batches [][]string
+	c := newStubClient(t, map[string]stubHandler{
+		"/room/v1/Room/get_status_info_by_uids": func(params url.Values) (any, error) {
+			uids := params["uids[]"]
+			batches = append(batches, uids)
+			result := make(map[string]any)
+			for _, uid := range uids {
+				// 只有偶数mid的主播有直播间
+				if mid, _ := strconv.Atoi(uid); mid%2 == 0 {
+					result[uid] = map[string]any{"uid": mid, "room_id": mid * 10, "live_status": 1}
+				}
+			}
+			if len(result) == 0 {
+				// 没有结果时返回的是空数组而不是空对象
+				return []any{}, nil
+			}
+			return result, nil
+		},
+	})
+
+	uids := make([]int, 0, 120)
+	for i := 1; i <= 120; i++ {
+		uids = append(uids, i)
+	}
+	statuses, err := c.GetLiveStatusByUids(GetLiveStatusByUidsParam{Uids: uids})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 3 || len(batches[0]) != 50 || len(batches[1]) != 50 || len(batches[2]) != 20 {
+		t.Fatalf("unexpected batches: %v", batches)
+	}
+	if batches[1][0] != "51" || batches[2][19] != "120" {
+		t.Fatalf("unexpected batch content: %v", batches)
+	}
+	if len(statuses) != 60 || statuses[120].RoomId != 1200 || statuses[2].LiveStatus != 1 || statuses[1] != nil {
+		t.Fatalf("unexpected statuses: %d, %+v", len(statuses), statuses[120])
+	}
+
+	batches = nil
+	statuses, err = c.GetLiveStatusByUids(GetLiveStatusByUidsParam{Uids: []int{1, 3}})
+	if err != nil || len(statuses) != 0 || len(batches) != 1 {
+		t.Fatalf("unexpected empty result: %v, %v, %v", statuses, err, batches)
+	}
+
+	batches = nil
+	statuses, err = c.GetLiveStatusByUids(GetLiveStatusByUidsParam{})
+	if err != nil || len(statuses) != 0 || len(batches) != 0 {
+		t.Fatalf("no request expected for empty uids: %v, %v, %v", statuses, err, batches)
+	}
+}
+
+func TestGetLiveRoomBaseInfo(t *testing.T) {
+	var batches [][]string
+	c := newStubClient(t, map[string]stubHandler{
+		"/xlive/web-room/v1/index/getRoomBaseInfo": func(params url.Values) (any, error) {
+			if params.Get("req_biz") != "web_room_componet" {
+				t.Errorf("unexpected req_biz: %s", params.Get("req_biz"))
+			}
+			roomIds := params["room_ids"]
+			batches = append(batches, roomIds)
+			if len(roomIds) == 1 {
+				return map[string]any{"by_room_ids": []any{}}, nil
+			}
+			rooms := make(map[string]any, len(roomIds))
+			for _, roomId := range roomIds {
+				id, _ := strconv.Atoi(roomId)
+				rooms[roomId] = map[string]any{"room_id": id, "uid": id + 1, "title": "直播间" + roomId}
+			}
+			return map[string]any{"by_room_ids": rooms}, nil
+		},
+	})
+
+	roomIds := make([]int, 0, 120)
+	for i := 1; i <= 120; i++ {
+		roomIds = append(roomIds, 1000+i)
+	}
+	rooms, err := c.GetLiveRoomBaseInfo(GetLiveRoomBaseInfoParam{RoomIds: roomIds})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 3 || len(batches[0]) != 50 || len(batches[1]) != 50 || len(batches[2]) != 20 || batches[2][0] != "1101" {
+		t.Fatalf("unexpected batches: %v", batches)
+	}
+	if len(rooms) != 120 || rooms[1001].Uid != 1002 || rooms[1120].Title != "直播间1120" {
+		t.Fatalf("unexpected rooms: %d, %+v", len(rooms), rooms[1120])
+	}
+
+	rooms, err = c.GetLiveRoomBaseInfo(GetLiveRoomBaseInfoParam{RoomIds: []int{1}})
+	if err != nil || len(rooms) != 0 {
+		t.Fatalf("unexpected empty result: %v, %v", rooms, err)
+	}
+}
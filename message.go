@@ -143,55 +143,57 @@ type GetPrivateMessageListParam struct {
 	MobiApp     string `json:"mobi_app,omitempty" request:"query,omitempty"` // 设备
 }
 
+type PrivateMessageSession struct {
+	TalkerId    int64  `json:"talker_id"`
+	SessionType int    `json:"session_type"`
+	AtSeqno     int    `json:"at_seqno"`
+	TopTs       int    `json:"top_ts"`
+	GroupName   string `json:"group_name"`
+	GroupCover  string `json:"group_cover"`
+	IsFollow    int    `json:"is_follow"`
+	IsDnd       int    `json:"is_dnd"`
+	AckSeqno    int64  `json:"ack_seqno"`
+	AckTs       int64  `json:"ack_ts"`
+	SessionTs   int64  `json:"session_ts"`
+	UnreadCount int    `json:"unread_count"`
+	LastMsg     struct {
+		SenderUid      int64  `json:"sender_uid"`
+		ReceiverType   int    `json:"receiver_type"`
+		ReceiverId     int    `json:"receiver_id"`
+		MsgType        int    `json:"msg_type"`
+		Content        string `json:"content"`
+		MsgSeqno       int64  `json:"msg_seqno"`
+		Timestamp      int    `json:"timestamp"`
+		MsgKey         int64  `json:"msg_key"`
+		MsgStatus      int    `json:"msg_status"`
+		NotifyCode     string `json:"notify_code"`
+		NewFaceVersion int    `json:"new_face_version,omitempty"`
+	} `json:"last_msg"`
+	GroupType         int   `json:"group_type"`
+	CanFold           int   `json:"can_fold"`
+	Status            int   `json:"status"`
+	MaxSeqno          int64 `json:"max_seqno"`
+	NewPushMsg        int   `json:"new_push_msg"`
+	Setting           int   `json:"setting"`
+	IsGuardian        int   `json:"is_guardian"`
+	IsIntercept       int   `json:"is_intercept"`
+	IsTrust           int   `json:"is_trust"`
+	SystemMsgType     int   `json:"system_msg_type"`
+	LiveStatus        int   `json:"live_status"`
+	BizMsgUnreadCount int   `json:"biz_msg_unread_count"`
+	AccountInfo       struct {
+		Name   string `json:"name"`
+		PicUrl string `json:"pic_url"`
+	} `json:"account_info,omitempty"`
+}
+
 type PrivateMessageList struct {
-	SessionList []struct {
-		TalkerId    int64  `json:"talker_id"`
-		SessionType int    `json:"session_type"`
-		AtSeqno     int    `json:"at_seqno"`
-		TopTs       int    `json:"top_ts"`
-		GroupName   string `json:"group_name"`
-		GroupCover  string `json:"group_cover"`
-		IsFollow    int    `json:"is_follow"`
-		IsDnd       int    `json:"is_dnd"`
-		AckSeqno    int64  `json:"ack_seqno"`
-		AckTs       int64  `json:"ack_ts"`
-		SessionTs   int64  `json:"session_ts"`
-		UnreadCount int    `json:"unread_count"`
-		LastMsg     struct {
-			SenderUid      int64  `json:"sender_uid"`
-			ReceiverType   int    `json:"receiver_type"`
-			ReceiverId     int    `json:"receiver_id"`
-			MsgType        int    `json:"msg_type"`
-			Content        string `json:"content"`
-			MsgSeqno       int64  `json:"msg_seqno"`
-			Timestamp      int    `json:"timestamp"`
-			MsgKey         int64  `json:"msg_key"`
-			MsgStatus      int    `json:"msg_status"`
-			NotifyCode     string `json:"notify_code"`
-			NewFaceVersion int    `json:"new_face_version,omitempty"`
-		} `json:"last_msg"`
-		GroupType         int   `json:"group_type"`
-		CanFold           int   `json:"can_fold"`
-		Status            int   `json:"status"`
-		MaxSeqno          int64 `json:"max_seqno"`
-		NewPushMsg        int   `json:"new_push_msg"`
-		Setting           int   `json:"setting"`
-		IsGuardian        int   `json:"is_guardian"`
-		IsIntercept       int   `json:"is_intercept"`
-		IsTrust           int   `json:"is_trust"`
-		SystemMsgType     int   `json:"system_msg_type"`
-		LiveStatus        int   `json:"live_status"`
-		BizMsgUnreadCount int   `json:"biz_msg_unread_count"`
-		AccountInfo       struct {
-			Name   string `json:"name"`
-			PicUrl string `json:"pic_url"`
-		} `json:"account_info,omitempty"`
-	} `json:"session_list"`
-	HasMore             int              `json:"has_more"`
-	AntiDisturbCleaning bool             `json:"anti_disturb_cleaning"`
-	IsAddressListEmpty  int              `json:"is_address_list_empty"`
-	SystemMsg           map[string]int64 `json:"system_msg"`
-	ShowLevel           bool             `json:"show_level"`
+	SessionList         []PrivateMessageSession `json:"session_list"`
+	HasMore             int                     `json:"has_more"`
+	AntiDisturbCleaning bool                    `json:"anti_disturb_cleaning"`
+	IsAddressListEmpty  int                     `json:"is_address_list_empty"`
+	SystemMsg           map[string]int64        `json:"system_msg"`
+	ShowLevel           bool                    `json:"show_level"`
 }
 
 // GetPrivateMessageList 获取消息列表 session_type，1：系统，2：用户，3：应援团
@@ -204,3 +206,36 @@ func (c *Client) GetPrivateMessageList(param GetPrivateMessageListParam) (*Priva
 	)
 	return execute[*PrivateMessageList](c, method, url, param)
 }
+
+type GetNewPrivateMessageSessionsParam struct {
+	BeginTs int64  `json:"begin_ts"`                                     // 起始时间。微秒级时间戳，即 PrivateMessageSession.SessionTs
+	Build   int    `json:"build,omitempty" request:"query,omitempty"`    // 未知。默认是0
+	MobiApp string `json:"mobi_app,omitempty" request:"query,omitempty"` // 设备。web
+}
+
+// GetNewPrivateMessageSessions 获取在 begin_ts 之后有新消息的会话列表
+func (c *Client) GetNewPrivateMessageSessions(param GetNewPrivateMessageSessionsParam) (*PrivateMessageList, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.vc.bilibili.com/session_svr/v1/session_svr/new_sessions"
+	)
+	return execute[*PrivateMessageList](c, method, url, param)
+}
+
+type UpdatePrivateMessageAckParam struct {
+	TalkerId    int    `json:"talker_id"`                                    // 聊天对象的uid
+	SessionType int    `json:"session_type"`                                 // 聊天对象的类型。1为用户，2为粉丝团
+	AckSeqno    int    `json:"ack_seqno"`                                    // 已读的最大消息序列号
+	Build       int    `json:"build,omitempty" request:"query,omitempty"`    // 未知。默认是0
+	MobiApp     string `json:"mobi_app,omitempty" request:"query,omitempty"` // 设备。web
+}
+
+// UpdatePrivateMessageAck 将会话中 AckSeqno 及之前的消息设为已读
+func (c *Client) UpdatePrivateMessageAck(param UpdatePrivateMessageAckParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.vc.bilibili.com/session_svr/v1/session_svr/update_ack"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
@@ -0,0 +1,375 @@
+package bilibili
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// BotContext 私信机器人处理一条消息时的上下文
+type BotContext struct {
+	context.Context
+	Bot     *Bot                 // 所属的机器人
+	Event   *PrivateMessageEvent // 收到的私信
+	Content MessageContent       // 解析后的私信内容，解析失败时为 *UnknownContent
+	Text    string               // 文字内容，非文字消息时为空
+	Args    []string             // 使用 OnCommand 匹配时为命令后的参数，使用 OnRegexp 匹配时为正则表达式的子匹配
+}
+
+// SenderId 返回发送者的mid
+func (ctx *BotContext) SenderId() int {
+	return ctx.Event.Message.SenderUid
+}
+
+// SessionType 返回会话类型。1为用户，2为粉丝团
+func (ctx *BotContext) SessionType() int {
+	return ctx.Event.SessionType
+}
+
+// IsSystem 是否为通知或者系统推送的卡片消息（ MsgType 为10、12或13）
+func (ctx *BotContext) IsSystem() bool {
+	switch ctx.Event.Message.MsgType {
+	case 10, 12, 13:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sender 通过 GetUserCard 获取发送者的名片，结果会被缓存10分钟
+func (ctx *BotContext) Sender() (*UserCard, error) {
+	return ctx.Bot.userCard(ctx.SenderId())
+}
+
+// Reply 回复文字私信。粉丝团中的消息也会以私信回复给发送者本人
+func (ctx *BotContext) Reply(text string) error {
+	_, err := ctx.Bot.client.SendPrivateTextMessage(ctx.SenderId(), text)
+	return err
+}
+
+// ReplyImage 回复图片私信
+func (ctx *BotContext) ReplyImage(fileName string, file io.Reader) error {
+	_, err := ctx.Bot.client.SendPrivateImageMessage(ctx.SenderId(), fileName, file)
+	return err
+}
+
+// BotHandler 私信处理函数
+type BotHandler func(ctx *BotContext) error
+
+// BotMiddleware 私信处理中间件，可以在调用 next 前后做额外的处理，不调用 next 则消息不会被继续处理
+type BotMiddleware func(next BotHandler) BotHandler
+
+type botRoute struct {
+	match   func(ctx *BotContext) bool
+	handler BotHandler
+}
+
+type botUserCard struct {
+	card     *UserCard
+	expireAt time.Time
+}
+
+// Bot 私信机器人。通过 PrivateMessageSyncer 接收私信，按照注册顺序匹配路由，第一个匹配上的路由会处理该消息
+type Bot struct {
+	client      *Client
+	syncer      *PrivateMessageSyncer
+	routes      []botRoute
+	fallback    BotHandler
+	middlewares []BotMiddleware
+	filter      func(ctx *BotContext) bool
+	onError     func(ctx *BotContext, err error)
+
+	mu    sync.Mutex
+	cards map[int]botUserCard
+}
+
+// NewBot 返回一个私信机器人，默认使用 NewPrivateMessageSyncer 接收私信，只处理用户会话中的非系统消息
+func (c *Client) NewBot() *Bot {
+	return &Bot{
+		client: c,
+		syncer: c.NewPrivateMessageSyncer(),
+		filter: defaultBotFilter,
+		cards:  make(map[int]botUserCard),
+	}
+}
+
+// defaultBotFilter 只处理用户会话，忽略粉丝团会话以及通知、系统推送等消息
+func defaultBotFilter(ctx *BotContext) bool {
+	return ctx.SessionType() == 1 && !ctx.IsSystem()
+}
+
+// WithSyncer 设置接收私信的同步器，例如需要持久化同步状态时
+func (b *Bot) WithSyncer(syncer *PrivateMessageSyncer) *Bot {
+	b.syncer = syncer
+	return b
+}
+
+// WithErrorHandler 设置处理出错时的回调，默认忽略错误
+func (b *Bot) WithErrorHandler(onError func(ctx *BotContext, err error)) *Bot {
+	b.onError = onError
+	return b
+}
+
+// WithFilter 设置需要处理的消息，filter 返回 false 的消息会被直接忽略，不会经过中间件和路由。
+// 默认只处理用户会话中的非系统消息，需要处理粉丝团消息时可以通过 BotContext.SessionType 判断
+func (b *Bot) WithFilter(filter func(ctx *BotContext) bool) *Bot {
+	b.filter = filter
+	return b
+}
+
+// Use 添加中间件，先添加的中间件在外层
+func (b *Bot) Use(middlewares ...BotMiddleware) *Bot {
+	b.middlewares = append(b.middlewares, middlewares...)
+	return b
+}
+
+// OnKeyword 文字消息中包含 keyword 时由 handler 处理
+func (b *Bot) OnKeyword(keyword string, handler BotHandler) *Bot {
+	b.routes = append(b.routes, botRoute{
+		match:   func(ctx *BotContext) bool { return strings.Contains(ctx.Text, keyword) },
+		handler: handler,
+	})
+	return b
+}
+
+// OnRegexp 文字消息匹配正则表达式时由 handler 处理，子匹配会放在 BotContext.Args 中
+func (b *Bot) OnRegexp(re *regexp.Regexp, handler BotHandler) *Bot {
+	b.routes = append(b.routes, botRoute{
+		match: func(ctx *BotContext) bool {
+			matches := re.FindStringSubmatch(ctx.Text)
+			if matches == nil {
+				return false
+			}
+			ctx.Args = matches[1:]
+			return true
+		},
+		handler: handler,
+	})
+	return b
+}
+
+// OnCommand 文字消息以命令开头时由 handler 处理，例如 command 为 /help 时可以匹配“/help”和“/help 1”，
+// 不能匹配“/helpme”。命令后以空白分隔的参数会放在 BotContext.Args 中
+func (b *Bot) OnCommand(command string, handler BotHandler) *Bot {
+	b.routes = append(b.routes, botRoute{
+		match: func(ctx *BotContext) bool {
+			rest, ok := strings.CutPrefix(strings.TrimSpace(ctx.Text), command)
+			if r, _ := utf8.DecodeRuneInString(rest); !ok || (len(rest) > 0 && !unicode.IsSpace(r)) {
+				return false
+			}
+			ctx.Args = strings.Fields(rest)
+			return true
+		},
+		handler: handler,
+	})
+	return b
+}
+
+// OnMessage 设置没有匹配到任何路由时的处理函数，包括非文字消息
+func (b *Bot) OnMessage(handler BotHandler) *Bot {
+	b.fallback = handler
+	return b
+}
+
+// Run 开始接收并处理私信，直到 ctx 被取消
+func (b *Bot) Run(ctx context.Context) error {
+	return b.syncer.Run(ctx, func(event *PrivateMessageEvent) {
+		botCtx, err := b.Handle(ctx, event)
+		if err != nil && b.onError != nil {
+			b.onError(botCtx, err)
+		}
+	})
+}
+
+// Handle 处理一条私信，返回处理时使用的上下文。被 WithFilter 忽略的私信直接返回 nil 错误。通常不需要直接调用，由 Run 调用
+func (b *Bot) Handle(ctx context.Context, event *PrivateMessageEvent) (*BotContext, error) {
+	botCtx := &BotContext{Context: ctx, Bot: b, Event: event}
+	content, err := event.Message.Decode()
+	if err != nil {
+		content = &UnknownContent{Type: event.Message.MsgType, Raw: event.Message.Content}
+	}
+	botCtx.Content = content
+	if text, ok := content.(*TextContent); ok {
+		botCtx.Text = text.Content
+	}
+	if b.filter != nil && !b.filter(botCtx) {
+		return botCtx, nil
+	}
+	handler := b.dispatch
+	for i := len(b.middlewares) - 1; i >= 0; i-- {
+		handler = b.middlewares[i](handler)
+	}
+	return botCtx, handler(botCtx)
+}
+
+func (b *Bot) dispatch(ctx *BotContext) error {
+	if len(ctx.Text) > 0 {
+		for _, route := range b.routes {
+			if route.match(ctx) {
+				return route.handler(ctx)
+			}
+		}
+	}
+	if b.fallback != nil {
+		return b.fallback(ctx)
+	}
+	return nil
+}
+
+func (b *Bot) userCard(mid int) (*UserCard, error) {
+	now := time.Now()
+	b.mu.Lock()
+	cached, ok := b.cards[mid]
+	b.mu.Unlock()
+	if ok && now.Before(cached.expireAt) {
+		return cached.card, nil
+	}
+	card, err := b.client.GetUserCard(GetUserCardParam{Mid: mid})
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for k, v := range b.cards {
+		if now.After(v.expireAt) {
+			delete(b.cards, k)
+		}
+	}
+	b.cards[mid] = botUserCard{card: card, expireAt: now.Add(10 * time.Minute)}
+	return card, nil
+}
+
+// BotLogMiddleware 使用 logger 记录收到的每条私信以及处理结果
+func BotLogMiddleware(logger *slog.Logger) BotMiddleware {
+	return func(next BotHandler) BotHandler {
+		return func(ctx *BotContext) error {
+			start := time.Now()
+			err := next(ctx)
+			attrs := []any{
+				slog.Int("sender", ctx.SenderId()),
+				slog.Int("msg_type", ctx.Event.Message.MsgType),
+				slog.String("text", ctx.Text),
+				slog.Duration("cost", time.Since(start)),
+			}
+			if err != nil {
+				logger.ErrorContext(ctx, "处理私信失败", append(attrs, slog.Any("error", err))...)
+			} else {
+				logger.InfoContext(ctx, "处理私信", attrs...)
+			}
+			return err
+		}
+	}
+}
+
+// BotBlocklistMiddleware 忽略 blocked 返回 true 的用户发来的私信
+func BotBlocklistMiddleware(blocked func(mid int) bool) BotMiddleware {
+	return func(next BotHandler) BotHandler {
+		return func(ctx *BotContext) error {
+			if blocked(ctx.SenderId()) {
+				return nil
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// ErrBotRateLimited 用户发送私信过于频繁，被 BotRateLimitMiddleware 或者 BotCooldownMiddleware 拦截
+var ErrBotRateLimited = errors.New("私信过于频繁")
+
+// BotRateLimitMiddleware 限制每个用户在 window 时间内最多被处理 limit 条私信，超出的私信会被忽略并返回 ErrBotRateLimited
+func BotRateLimitMiddleware(limit int, window time.Duration) BotMiddleware {
+	limiter := &botRateLimiter{limit: limit, window: window, hits: make(map[int][]time.Time)}
+	return func(next BotHandler) BotHandler {
+		return func(ctx *BotContext) error {
+			if !limiter.allow(ctx.SenderId(), time.Now()) {
+				return ErrBotRateLimited
+			}
+			return next(ctx)
+		}
+	}
+}
+
+type botRateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	hits    map[int][]time.Time
+	sweepAt time.Time
+}
+
+// allow 判断 mid 在 now 时能否被处理，并且每隔 window 清理一次已经过期的用户
+func (l *botRateLimiter) allow(mid int, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !now.Before(l.sweepAt) {
+		for k, v := range l.hits {
+			if len(v) == 0 || now.Sub(v[len(v)-1]) >= l.window {
+				delete(l.hits, k)
+			}
+		}
+		l.sweepAt = now.Add(l.window)
+	}
+	recent := l.hits[mid][:0]
+	for _, t := range l.hits[mid] {
+		if now.Sub(t) < l.window {
+			recent = append(recent, t)
+		}
+	}
+	allowed := len(recent) < l.limit
+	if allowed {
+		recent = append(recent, now)
+	}
+	if len(recent) > 0 {
+		l.hits[mid] = recent
+	} else {
+		delete(l.hits, mid)
+	}
+	return allowed
+}
+
+// BotCooldownMiddleware 每个用户的私信被处理后，在 cooldown 时间内发来的私信会被忽略并返回 ErrBotRateLimited
+func BotCooldownMiddleware(cooldown time.Duration) BotMiddleware {
+	limiter := &botCooldown{cooldown: cooldown, last: make(map[int]time.Time)}
+	return func(next BotHandler) BotHandler {
+		return func(ctx *BotContext) error {
+			if !limiter.allow(ctx.SenderId(), time.Now()) {
+				return ErrBotRateLimited
+			}
+			return next(ctx)
+		}
+	}
+}
+
+type botCooldown struct {
+	mu       sync.Mutex
+	cooldown time.Duration
+	last     map[int]time.Time
+	sweepAt  time.Time
+}
+
+// allow 判断 mid 在 now 时能否被处理，并且每隔 cooldown 清理一次已经冷却完毕的用户
+func (c *botCooldown) allow(mid int, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !now.Before(c.sweepAt) {
+		for k, t := range c.last {
+			if now.Sub(t) >= c.cooldown {
+				delete(c.last, k)
+			}
+		}
+		c.sweepAt = now.Add(c.cooldown)
+	}
+	if t, ok := c.last[mid]; ok && now.Sub(t) < c.cooldown {
+		return false
+	}
+	c.last[mid] = now
+	return true
+}
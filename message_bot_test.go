@@ -0,0 +1,148 @@
+package bilibili
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestBot(t *testing.T) {
+	var got []string
+	record := func(name string) BotHandler {
+		return func(ctx *BotContext) error {
+			got = append(got, name)
+			for _, arg := range ctx.Args {
+				got = append(got, arg)
+			}
+			return nil
+		}
+	}
+	bot := New().NewBot().
+		Use(BotBlocklistMiddleware(func(mid int) bool { return mid == 2 })).
+		Use(BotRateLimitMiddleware(4, time.Minute)).
+		OnCommand("/help", record("help")).
+		OnRegexp(regexp.MustCompile(`^查询(\d+)$`), record("query")).
+		OnKeyword("你好", record("hello")).
+		OnMessage(record("fallback"))
+	send := func(sender, msgType int, content string) error {
+		_, err := bot.Handle(context.Background(), &PrivateMessageEvent{
+			SessionType: 1,
+			TalkerId:    sender,
+			Message:     Message{SenderUid: sender, MsgType: msgType, Content: content},
+		})
+		return err
+	}
+	for _, content := range []string{`{"content":"/help 1 2"}`, `{"content":"/helpme"}`, `{"content":"查询123"}`, `{"content":"你好啊"}`} {
+		if err := send(1, 1, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := send(2, 1, `{"content":"/help"}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(1, 1, `{"content":"/help"}`); !errors.Is(err, ErrBotRateLimited) {
+		t.Fatal("rate limit not work ", err)
+	}
+	expected := []string{"help", "1", "2", "fallback", "query", "123", "hello"}
+	if len(got) != len(expected) {
+		t.Fatal("routes not correct ", got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatal("routes not correct ", got)
+		}
+	}
+}
+
+func TestBotFilter(t *testing.T) {
+	var got []int
+	bot := New().NewBot().OnMessage(func(ctx *BotContext) error {
+		got = append(got, ctx.Event.Message.MsgSeqno)
+		return nil
+	})
+	send := func(bot *Bot, seqno, sessionType, msgType int) {
+		_, err := bot.Handle(context.Background(), &PrivateMessageEvent{
+			SessionType: sessionType,
+			TalkerId:    3,
+			Message:     Message{SenderUid: 3, MsgType: msgType, MsgSeqno: seqno, Content: `{"content":"你好"}`},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 默认忽略粉丝团会话和系统消息
+	send(bot, 1, 1, 1)
+	send(bot, 2, 2, 1)
+	send(bot, 3, 1, 12)
+	send(bot, 4, 1, 10)
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("unexpected handled messages: %v", got)
+	}
+	got = nil
+	bot.WithFilter(func(ctx *BotContext) bool { return !ctx.IsSystem() })
+	send(bot, 5, 2, 1)
+	send(bot, 6, 2, 13)
+	if len(got) != 1 || got[0] != 5 {
+		t.Fatalf("unexpected handled messages: %v", got)
+	}
+}
+
+func TestBotCooldownMiddleware(t *testing.T) {
+	var handled int
+	bot := New().NewBot().
+		Use(BotCooldownMiddleware(time.Minute)).
+		OnMessage(func(*BotContext) error {
+			handled++
+			return nil
+		})
+	send := func(sender int) error {
+		_, err := bot.Handle(context.Background(), &PrivateMessageEvent{
+			SessionType: 1,
+			TalkerId:    sender,
+			Message:     Message{SenderUid: sender, MsgType: 1, Content: `{"content":"你好"}`},
+		})
+		return err
+	}
+	if err := send(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := send(1); !errors.Is(err, ErrBotRateLimited) {
+		t.Fatal("cooldown not work ", err)
+	}
+	// 冷却只针对同一个用户
+	if err := send(2); err != nil || handled != 2 {
+		t.Fatal("other users should not be limited ", err, handled)
+	}
+
+	// 冷却完毕的用户会被清理
+	cooldown := &botCooldown{cooldown: time.Minute, last: make(map[int]time.Time)}
+	now := time.Now()
+	for mid := range 100 {
+		if !cooldown.allow(mid, now) {
+			t.Fatalf("user %d should be allowed", mid)
+		}
+	}
+	if cooldown.allow(1, now.Add(30*time.Second)) {
+		t.Fatal("user should be cooling down")
+	}
+	if !cooldown.allow(1, now.Add(time.Minute)) || len(cooldown.last) != 1 {
+		t.Fatalf("expected stale users evicted, got %d", len(cooldown.last))
+	}
+}
+
+func TestBotRateLimiterEvict(t *testing.T) {
+	limiter := &botRateLimiter{limit: 2, window: time.Minute, hits: make(map[int][]time.Time)}
+	now := time.Now()
+	for mid := range 100 {
+		limiter.allow(mid, now)
+	}
+	if !limiter.allow(1, now.Add(time.Second)) || limiter.allow(1, now.Add(2*time.Second)) {
+		t.Fatal("rate limit not work")
+	}
+	if !limiter.allow(1, now.Add(time.Minute+time.Second)) || len(limiter.hits) != 1 {
+		t.Fatalf("expected stale users evicted, got %d", len(limiter.hits))
+	}
+}
@@ -0,0 +1,199 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// MessageContent 解析后的私信内容，具体类型为 *TextContent 、 *ImageContent 、 *RecallContent 、 *ShareContent 、
+// *NotificationContent 、 *VideoPushContent 、 *SystemContent ，无法识别的类型为 *UnknownContent
+type MessageContent interface {
+	MsgType() int
+}
+
+// TextContent 文字消息
+type TextContent struct {
+	Content string `json:"content"` // 文字内容
+}
+
+func (*TextContent) MsgType() int { return 1 }
+
+// ImageContent 图片消息
+type ImageContent struct {
+	Url       string  `json:"url"`       // 图片url
+	Height    int     `json:"height"`    // 图片高度
+	Width     int     `json:"width"`     // 图片宽度
+	ImageType string  `json:"imageType"` // 图片格式。例如 jpeg 、 png
+	Original  int     `json:"original"`  // 是否为原图。1
+	Size      float64 `json:"size"`      // 图片大小。单位为KB
+}
+
+func (*ImageContent) MsgType() int { return 2 }
+
+// RecallContent 撤回的消息
+type RecallContent struct {
+	MsgKey int // 被撤回的消息的 MsgKey
+}
+
+func (*RecallContent) MsgType() int { return 5 }
+
+// ShareContent 分享的稿件、专栏等
+type ShareContent struct {
+	Author   string `json:"author"`   // 作者昵称
+	Headline string `json:"headline"` // 标题
+	Id       int    `json:"id"`       // 稿件avid、专栏cvid等
+	Source   int    `json:"source"`   // 分享来源。5：视频。6：专栏。等等
+	Thumb    string `json:"thumb"`    // 封面url
+	Url      string `json:"url"`      // 跳转url
+	Bvid     string `json:"bvid"`     // 稿件bvid。仅分享视频时有此项
+}
+
+func (*ShareContent) MsgType() int { return 7 }
+
+// NotificationContent 通知消息，例如UP主小助手的通知
+type NotificationContent struct {
+	Title    string `json:"title"`     // 标题
+	Text     string `json:"text"`      // 正文
+	JumpText string `json:"jump_text"` // 跳转按钮文字
+	JumpUri  string `json:"jump_uri"`  // 跳转url
+}
+
+func (*NotificationContent) MsgType() int { return 10 }
+
+// VideoPushContent UP主推送的视频
+type VideoPushContent struct {
+	Title   string `json:"title"`    // 视频标题
+	Times   int    `json:"times"`    // 视频时长。单位为秒
+	Cover   string `json:"cover"`    // 视频封面url
+	JumpUri string `json:"jump_uri"` // 跳转url
+	Desc    string `json:"desc"`     // 视频简介
+	Rid     int    `json:"rid"`      // 视频avid
+	Bvid    string `json:"bvid"`     // 视频bvid
+}
+
+func (*VideoPushContent) MsgType() int { return 11 }
+
+// SystemContent 系统推送的卡片消息（ MsgType 为12或13），不同来源的字段差异很大，只解析了常用字段，其余字段见 Raw
+type SystemContent struct {
+	Type    int            `json:"-"`        // 消息类型。12或13
+	Title   string         `json:"title"`    // 标题
+	Text    string         `json:"text"`     // 正文
+	JumpUri string         `json:"jump_uri"` // 跳转url
+	Raw     map[string]any `json:"-"`        // 原始内容
+}
+
+func (m *SystemContent) MsgType() int { return m.Type }
+
+// UnknownContent 暂不支持解析的私信内容
+type UnknownContent struct {
+	Type int    // 消息类型
+	Raw  string // 原始内容
+}
+
+func (m *UnknownContent) MsgType() int { return m.Type }
+
+// Decode 按照 MsgType 解析 Content
+func (m *Message) Decode() (MessageContent, error) {
+	var content MessageContent
+	switch m.MsgType {
+	case 1:
+		content = &TextContent{}
+	case 2:
+		content = &ImageContent{}
+	case 5:
+		// 撤回消息的内容是被撤回消息的 msg_key
+		return &RecallContent{MsgKey: cast.ToInt(strings.Trim(m.Content, `"`))}, nil
+	case 7:
+		content = &ShareContent{}
+	case 10:
+		content = &NotificationContent{}
+	case 11:
+		content = &VideoPushContent{}
+	case 12, 13:
+		system := &SystemContent{Type: m.MsgType}
+		if err := json.Unmarshal([]byte(m.Content), &system.Raw); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		content = system
+	default:
+		return &UnknownContent{Type: m.MsgType, Raw: m.Content}, nil
+	}
+	if err := json.Unmarshal([]byte(m.Content), content); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return content, nil
+}
+
+// sendPrivateMessage 以当前登录的用户发送私信，content 为已经序列化的消息内容
+func (c *Client) sendPrivateMessage(receiverId, msgType int, content string) (*SendPrivateMessageResult, error) {
+	senderUid := cast.ToInt(c.getCookie("DedeUserID"))
+	if senderUid == 0 {
+		return nil, errors.New("B站登录过期")
+	}
+	return c.SendPrivateMessage(SendPrivateMessageParam{
+		SenderUid:    senderUid,
+		ReceiverId:   receiverId,
+		ReceiverType: 1,
+		MsgType:      msgType,
+		Timestamp:    int(time.Now().Unix()),
+		Content:      json.Number(content),
+	})
+}
+
+// SendPrivateTextMessage 给用户发送文字私信
+func (c *Client) SendPrivateTextMessage(receiverId int, text string) (*SendPrivateMessageResult, error) {
+	buf, err := json.Marshal(&TextContent{Content: text})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c.sendPrivateMessage(receiverId, 1, string(buf))
+}
+
+// countingReader 记录读取的字节数，用于计算上传图片的大小
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += n
+	return n, err
+}
+
+// SendPrivateImageMessage 上传图片并给用户发送图片私信
+func (c *Client) SendPrivateImageMessage(receiverId int, fileName string, file io.Reader) (*SendPrivateMessageResult, error) {
+	reader := &countingReader{Reader: file}
+	url, size, err := c.UploadDynamicBfs(fileName, reader, "daily")
+	if err != nil {
+		return nil, err
+	}
+	imageType := strings.TrimPrefix(strings.ToLower(path.Ext(fileName)), ".")
+	if imageType == "jpg" {
+		imageType = "jpeg"
+	}
+	buf, err := json.Marshal(&ImageContent{
+		Url:       url,
+		Height:    size.Height,
+		Width:     size.Width,
+		ImageType: imageType,
+		Original:  1,
+		Size:      float64(reader.n) / 1024,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c.sendPrivateMessage(receiverId, 2, string(buf))
+}
+
+// RecallPrivateMessage 撤回自己发给用户的私信，msgKey 为发送时返回的 SendPrivateMessageResult.MsgKey
+func (c *Client) RecallPrivateMessage(receiverId, msgKey int) (*SendPrivateMessageResult, error) {
+	return c.sendPrivateMessage(receiverId, 5, strconv.Itoa(msgKey))
+}
@@ -0,0 +1,46 @@
+package bilibili
+
+import "testing"
+
+func TestMessageDecode(t *testing.T) {
+	m := &Message{MsgType: 1, Content: `{"content":"你好[doge]"}`}
+	content, err := m.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text, ok := content.(*TextContent); !ok || text.Content != "你好[doge]" {
+		t.Fatal("text content not correct ", content)
+	}
+
+	m = &Message{MsgType: 2, Content: `{"url":"https://i0.hdslb.com/a.png","height":100,"width":200,"imageType":"png","original":1,"size":12.5}`}
+	if content, err = m.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if image, ok := content.(*ImageContent); !ok || image.Width != 200 || image.Size != 12.5 {
+		t.Fatal("image content not correct ", content)
+	}
+
+	m = &Message{MsgType: 5, Content: "7123456789012345678"}
+	if content, err = m.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if recall, ok := content.(*RecallContent); !ok || recall.MsgKey != 7123456789012345678 {
+		t.Fatal("recall content not correct ", content)
+	}
+
+	m = &Message{MsgType: 12, Content: `{"title":"标题","text":"正文","extra":1}`}
+	if content, err = m.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if system, ok := content.(*SystemContent); !ok || system.MsgType() != 12 || system.Title != "标题" || system.Raw["extra"] != 1.0 {
+		t.Fatal("system content not correct ", content)
+	}
+
+	m = &Message{MsgType: 99, Content: "raw"}
+	if content, err = m.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if unknown, ok := content.(*UnknownContent); !ok || unknown.Raw != "raw" {
+		t.Fatal("unknown content not correct ", content)
+	}
+}
@@ -0,0 +1,303 @@
+package bilibili
+
+import (
+	"regexp"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+// MessageFeedCategory 消息中心的消息分类
+type MessageFeedCategory string
+
+const (
+	MessageFeedReply  MessageFeedCategory = "reply"   // 回复我的
+	MessageFeedAt     MessageFeedCategory = "at"      // @我的
+	MessageFeedLike   MessageFeedCategory = "like"    // 收到的赞
+	MessageFeedSysMsg MessageFeedCategory = "sys_msg" // 系统通知
+)
+
+type MessageFeedUser struct {
+	Mid      int    `json:"mid"`      // 用户mid
+	Fans     int    `json:"fans"`     // 粉丝数
+	Nickname string `json:"nickname"` // 昵称
+	Avatar   string `json:"avatar"`   // 头像url
+	MidLink  string `json:"mid_link"` // 空间链接
+	Follow   bool   `json:"follow"`   // 是否关注了对方
+}
+
+type MessageFeedCursor struct {
+	IsEnd bool `json:"is_end"` // 是否已经到底
+	Id    int  `json:"id"`     // 下一页的 Id 参数
+	Time  int  `json:"time"`   // 下一页的时间参数，即 ReplyTime 、 AtTime 、 LikeTime
+}
+
+// MessageFeedCommentRef 消息对应的评论，可以用于 GetCommentReply 等接口
+type MessageFeedCommentRef struct {
+	Type int // 评论区类型代码
+	Oid  int // 目标评论区 id
+	Root int // 根评论 rpid ，为0时消息对应的评论就是一级评论
+	Rpid int // 消息对应的评论的 rpid
+}
+
+type ReplyFeedDetail struct {
+	SubjectId          int    `json:"subject_id"`           // 评论区 id ，即 oid
+	RootId             int    `json:"root_id"`              // 根评论 rpid
+	SourceId           int    `json:"source_id"`            // 对方的评论 rpid
+	TargetId           int    `json:"target_id"`            // 被回复的评论 rpid
+	Type               string `json:"type"`                 // 业务类型。reply：评论。video：视频。等等
+	BusinessId         int    `json:"business_id"`          // 评论区类型代码
+	Business           string `json:"business"`             // 评论区类型名称
+	Title              string `json:"title"`                // 被评论的内容的标题
+	Desc               string `json:"desc"`                 // 被评论的内容的描述
+	Image              string `json:"image"`                // 被评论的内容的图片url
+	Uri                string `json:"uri"`                  // 被评论的内容的跳转url
+	NativeUri          string `json:"native_uri"`           // APP 跳转 uri
+	DetailTitle        string `json:"detail_title"`         // 详细标题
+	RootReplyContent   string `json:"root_reply_content"`   // 根评论内容
+	SourceContent      string `json:"source_content"`       // 对方的评论内容
+	TargetReplyContent string `json:"target_reply_content"` // 被回复的评论内容
+	AtDetails          []any  `json:"at_details"`           // 评论中@的用户
+	HideReplyButton    bool   `json:"hide_reply_button"`    // 是否隐藏回复按钮
+	HideLikeButton     bool   `json:"hide_like_button"`     // 是否隐藏点赞按钮
+	LikeState          int    `json:"like_state"`           // 是否已点赞对方的评论。0：未点赞。1：已点赞
+}
+
+type ReplyFeedItem struct {
+	Id        int             `json:"id"`         // 消息id
+	User      MessageFeedUser `json:"user"`       // 回复者
+	Item      ReplyFeedDetail `json:"item"`       // 回复内容
+	Counts    int             `json:"counts"`     // 回复数
+	IsMulti   int             `json:"is_multi"`   // 是否为多条回复合并
+	ReplyTime int             `json:"reply_time"` // 回复时间。时间戳
+}
+
+// CommentRef 获取对方的评论所在的评论区和评论id
+func (item *ReplyFeedItem) CommentRef() MessageFeedCommentRef {
+	return MessageFeedCommentRef{
+		Type: item.Item.BusinessId,
+		Oid:  item.Item.SubjectId,
+		Root: item.Item.RootId,
+		Rpid: item.Item.SourceId,
+	}
+}
+
+type ReplyFeed struct {
+	Cursor     MessageFeedCursor `json:"cursor"`       // 分页信息
+	Items      []ReplyFeedItem   `json:"items"`        // 回复列表
+	LastViewAt int               `json:"last_view_at"` // 上次查看的时间。时间戳
+}
+
+type GetReplyFeedParam struct {
+	Id        int `json:"id,omitempty" request:"query,omitempty"`         // 上一页返回的 Cursor.Id 。获取第一页时不填
+	ReplyTime int `json:"reply_time,omitempty" request:"query,omitempty"` // 上一页返回的 Cursor.Time 。获取第一页时不填
+}
+
+// GetReplyFeed 获取回复我的消息（需要登录）。获取第一页时B站会把这一类消息全部设为已读
+func (c *Client) GetReplyFeed(param GetReplyFeedParam) (*ReplyFeed, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/msgfeed/reply"
+	)
+	return execute[*ReplyFeed](c, method, url, param)
+}
+
+type AtFeedDetail struct {
+	Type          string `json:"type"`           // 业务类型。reply：评论。dynamic：动态。等等
+	Business      string `json:"business"`       // 评论区类型名称
+	BusinessId    int    `json:"business_id"`    // 评论区类型代码
+	Title         string `json:"title"`          // 被评论的内容的标题
+	Image         string `json:"image"`          // 被评论的内容的图片url
+	Uri           string `json:"uri"`            // 跳转url
+	SourceContent string `json:"source_content"` // @我的内容
+	SourceId      int    `json:"source_id"`      // @我的评论 rpid
+	TargetId      int    `json:"target_id"`      // 被回复的评论 rpid
+	RootId        int    `json:"root_id"`        // 根评论 rpid
+	SubjectId     int    `json:"subject_id"`     // 评论区 id ，即 oid
+	NativeUri     string `json:"native_uri"`     // APP 跳转 uri
+	AtDetails     []any  `json:"at_details"`     // @的用户
+}
+
+type AtFeedItem struct {
+	Id     int             `json:"id"`      // 消息id
+	User   MessageFeedUser `json:"user"`    // @我的用户
+	Item   AtFeedDetail    `json:"item"`    // @我的内容
+	AtTime int             `json:"at_time"` // @的时间。时间戳
+}
+
+// CommentRef 获取@我的评论所在的评论区和评论id。仅 Item.Type 为 reply 时有意义
+func (item *AtFeedItem) CommentRef() MessageFeedCommentRef {
+	return MessageFeedCommentRef{
+		Type: item.Item.BusinessId,
+		Oid:  item.Item.SubjectId,
+		Root: item.Item.RootId,
+		Rpid: item.Item.SourceId,
+	}
+}
+
+type AtFeed struct {
+	Cursor MessageFeedCursor `json:"cursor"` // 分页信息
+	Items  []AtFeedItem      `json:"items"`  // @我的列表
+}
+
+type GetAtFeedParam struct {
+	Id     int `json:"id,omitempty" request:"query,omitempty"`      // 上一页返回的 Cursor.Id 。获取第一页时不填
+	AtTime int `json:"at_time,omitempty" request:"query,omitempty"` // 上一页返回的 Cursor.Time 。获取第一页时不填
+}
+
+// GetAtFeed 获取@我的消息（需要登录）。获取第一页时B站会把这一类消息全部设为已读
+func (c *Client) GetAtFeed(param GetAtFeedParam) (*AtFeed, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/msgfeed/at"
+	)
+	return execute[*AtFeed](c, method, url, param)
+}
+
+type LikeFeedDetail struct {
+	ItemId          int    `json:"item_id"`           // 被点赞的内容id
+	Pid             int    `json:"pid"`               // 父级id
+	Type            string `json:"type"`              // 业务类型。reply：评论。video：视频。dynamic：动态。等等
+	Business        string `json:"business"`          // 业务名称
+	BusinessId      int    `json:"business_id"`       // 业务id
+	ReplyBusinessId int    `json:"reply_business_id"` // 评论区类型代码
+	Title           string `json:"title"`             // 被点赞的内容的标题
+	Desc            string `json:"desc"`              // 被点赞的内容的描述
+	Image           string `json:"image"`             // 被点赞的内容的图片url
+	Uri             string `json:"uri"`               // 跳转url
+	DetailName      string `json:"detail_name"`       // 详细名称
+	NativeUri       string `json:"native_uri"`        // APP 跳转 uri
+	Ctime           int    `json:"ctime"`             // 被点赞的内容的发布时间。时间戳
+}
+
+type LikeFeedItem struct {
+	Id          int               `json:"id"`           // 消息id
+	Users       []MessageFeedUser `json:"users"`        // 最近点赞的用户，聚合后的点赞者列表
+	Item        LikeFeedDetail    `json:"item"`         // 被点赞的内容
+	Counts      int               `json:"counts"`       // 点赞总人数
+	LikeTime    int               `json:"like_time"`    // 最近一次点赞的时间。时间戳
+	NoticeState int               `json:"notice_state"` // 是否接收该内容的点赞通知。0：接收。1：不接收
+}
+
+type LikeFeed struct {
+	Latest struct { // 最新的点赞，仅第一页有数据
+		Items      []LikeFeedItem `json:"items"`        // 点赞列表
+		LastViewAt int            `json:"last_view_at"` // 上次查看的时间。时间戳
+	} `json:"latest"`
+	Total struct { // 全部点赞
+		Cursor MessageFeedCursor `json:"cursor"` // 分页信息
+		Items  []LikeFeedItem    `json:"items"`  // 点赞列表
+	} `json:"total"`
+}
+
+type GetLikeFeedParam struct {
+	Id       int `json:"id,omitempty" request:"query,omitempty"`        // 上一页返回的 Total.Cursor.Id 。获取第一页时不填
+	LikeTime int `json:"like_time,omitempty" request:"query,omitempty"` // 上一页返回的 Total.Cursor.Time 。获取第一页时不填
+}
+
+// GetLikeFeed 获取收到的赞（需要登录）。获取第一页时B站会把这一类消息全部设为已读
+func (c *Client) GetLikeFeed(param GetLikeFeedParam) (*LikeFeed, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/msgfeed/like"
+	)
+	return execute[*LikeFeed](c, method, url, param)
+}
+
+type SystemNotifySource struct {
+	Name string `json:"name"` // 来源名称
+	Logo string `json:"logo"` // 来源图标url
+}
+
+type SystemNotify struct {
+	Id      int                `json:"id"`      // 通知id
+	Cursor  int                `json:"cursor"`  // 游标，获取下一页时使用最后一条的游标
+	Type    int                `json:"type"`    // 通知类型
+	Title   string             `json:"title"`   // 标题
+	Content string             `json:"content"` // 正文。其中的链接格式为 #{文字}{"url"} ，可以使用 RichText 解析
+	Source  SystemNotifySource `json:"source"`  // 来源
+	TimeAt  string             `json:"time_at"` // 通知时间。YYYY-MM-DD HH:mm:ss
+}
+
+var regSystemNotifyLink = regexp.MustCompile(`#\{([^}]*)\}\{"([^"]*)"\}`)
+
+// RichText 将通知正文解析为富文本，正文中 #{文字}{"url"} 格式的内容会被识别为跳转链接
+func (n *SystemNotify) RichText() RichText {
+	var result RichText
+	pos := 0
+	for _, loc := range regSystemNotifyLink.FindAllStringSubmatchIndex(n.Content, -1) {
+		if loc[0] > pos {
+			result = append(result, RichTextSegment{Type: RichTextSegmentText, Text: n.Content[pos:loc[0]]})
+		}
+		result = append(result, RichTextSegment{
+			Type: RichTextSegmentLink,
+			Text: n.Content[loc[2]:loc[3]],
+			Url:  n.Content[loc[4]:loc[5]],
+		})
+		pos = loc[1]
+	}
+	if pos < len(n.Content) {
+		result = append(result, RichTextSegment{Type: RichTextSegmentText, Text: n.Content[pos:]})
+	}
+	return result
+}
+
+type SystemNotifyList struct {
+	SystemNotifyList []SystemNotify `json:"system_notify_list"` // 通知列表
+}
+
+type GetSystemNotifyParam struct {
+	Cursor   int    `json:"cursor,omitempty" request:"query,omitempty"` // 上一页最后一条通知的 Cursor 。获取第一页时不填
+	PageSize int    `json:"page_size" request:"query,default=20"`       // 每页数量。默认为20
+	MobiApp  string `json:"mobi_app" request:"query,default=web"`       // web
+}
+
+// GetSystemNotify 获取系统通知（需要登录）
+func (c *Client) GetSystemNotify(param GetSystemNotifyParam) (*SystemNotifyList, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://message.bilibili.com/x/sys-msg/query_user_notify"
+	)
+	return execute[*SystemNotifyList](c, method, url, param, fillCsrf(c))
+}
+
+type UpdateSystemNotifyCursorParam struct {
+	Cursor  int    `json:"cursor"`                               // 已读的最新一条通知的 Cursor
+	MobiApp string `json:"mobi_app" request:"query,default=web"` // web
+}
+
+// UpdateSystemNotifyCursor 将 Cursor 及之前的系统通知设为已读
+func (c *Client) UpdateSystemNotifyCursor(param UpdateSystemNotifyCursorParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://message.bilibili.com/x/sys-msg/update_cursor"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// MarkMessageFeedRead 将一类消息全部设为已读。
+//
+// 回复、@和点赞没有单独的已读接口，B站在获取这三类消息的第一页时会自动更新已读时间，
+// 因此这三类是通过获取一次第一页（对应的 GetReplyFeed 、 GetAtFeed 或 GetLikeFeed ）实现的，会多消耗一次请求，结果会被丢弃。
+// 系统通知先通过 GetSystemNotify 获取最新一条通知，再通过 UpdateSystemNotifyCursor 设为已读，没有通知时不会调用后者
+func (c *Client) MarkMessageFeedRead(category MessageFeedCategory) error {
+	var err error
+	switch category {
+	case MessageFeedReply:
+		_, err = c.GetReplyFeed(GetReplyFeedParam{})
+	case MessageFeedAt:
+		_, err = c.GetAtFeed(GetAtFeedParam{})
+	case MessageFeedLike:
+		_, err = c.GetLikeFeed(GetLikeFeedParam{})
+	case MessageFeedSysMsg:
+		var list *SystemNotifyList
+		if list, err = c.GetSystemNotify(GetSystemNotifyParam{}); err != nil || len(list.SystemNotifyList) == 0 {
+			return err
+		}
+		err = c.UpdateSystemNotifyCursor(UpdateSystemNotifyCursorParam{Cursor: list.SystemNotifyList[0].Cursor})
+	default:
+		err = errors.Errorf("未知的消息分类: %s", category)
+	}
+	return err
+}
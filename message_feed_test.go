@@ -0,0 +1,134 @@
+package bilibili
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSystemNotifyRichText(t *testing.T) {
+	n := &SystemNotify{Content: `你的稿件已通过审核，#{点击查看}{"https://www.bilibili.com/video/BV1L9Uoa9EUx"}。`}
+	r := n.RichText()
+	if len(r) != 3 || r[1].Type != RichTextSegmentLink || r[1].Text != "点击查看" ||
+		r[1].Url != "https://www.bilibili.com/video/BV1L9Uoa9EUx" || r[2].Text != "。" {
+		t.Fatal("rich text not correct ", r)
+	}
+	if r.PlainText() != "你的稿件已通过审核，点击查看。" {
+		t.Fatal("plain text not correct ", r.PlainText())
+	}
+}
+
+func TestMessageFeedCursor(t *testing.T) {
+	var requests []url.Values
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/msgfeed/reply": func(params url.Values) (any, error) {
+			requests = append(requests, params)
+			if len(params.Get("id")) == 0 {
+				return map[string]any{
+					"cursor": map[string]any{"is_end": false, "id": 10, "time": 1000},
+					"items":  []map[string]any{{"id": 11, "reply_time": 1100, "item": map[string]any{"subject_id": 5, "business_id": 1, "root_id": 6, "source_id": 7}}},
+				}, nil
+			}
+			return map[string]any{"cursor": map[string]any{"is_end": true}, "items": []map[string]any{{"id": 9, "reply_time": 900}}}, nil
+		},
+		"/x/msgfeed/at": func(params url.Values) (any, error) {
+			requests = append(requests, params)
+			return map[string]any{"cursor": map[string]any{"is_end": true}}, nil
+		},
+		"/x/msgfeed/like": func(params url.Values) (any, error) {
+			requests = append(requests, params)
+			return map[string]any{
+				"latest": map[string]any{"items": []map[string]any{{"id": 21, "like_time": 2100}}, "last_view_at": 2000},
+				"total":  map[string]any{"cursor": map[string]any{"is_end": false, "id": 20, "time": 2000}},
+			}, nil
+		},
+	})
+
+	// 使用上一页返回的游标获取下一页
+	var ids []int
+	param := GetReplyFeedParam{}
+	for {
+		feed, err := c.GetReplyFeed(param)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range feed.Items {
+			ids = append(ids, item.Id)
+		}
+		if feed.Cursor.IsEnd {
+			break
+		}
+		if ref := feed.Items[0].CommentRef(); ref.Oid != 5 || ref.Type != 1 || ref.Root != 6 || ref.Rpid != 7 {
+			t.Fatalf("unexpected comment ref: %+v", ref)
+		}
+		param = GetReplyFeedParam{Id: feed.Cursor.Id, ReplyTime: feed.Cursor.Time}
+	}
+	if len(ids) != 2 || ids[0] != 11 || ids[1] != 9 {
+		t.Fatalf("unexpected items: %v", ids)
+	}
+	if len(requests) != 2 || requests[1].Get("id") != "10" || requests[1].Get("reply_time") != "1000" {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+
+	requests = nil
+	if _, err := c.GetAtFeed(GetAtFeedParam{Id: 3, AtTime: 300}); err != nil {
+		t.Fatal(err)
+	}
+	like, err := c.GetLikeFeed(GetLikeFeedParam{Id: 4, LikeTime: 400})
+	if err != nil || like.Total.Cursor.Id != 20 || like.Total.Cursor.Time != 2000 || like.Latest.Items[0].LikeTime != 2100 {
+		t.Fatalf("unexpected like feed: %+v, %v", like, err)
+	}
+	if requests[0].Get("at_time") != "300" || requests[1].Get("id") != "4" || requests[1].Get("like_time") != "400" {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+
+	// 获取第一页时不带游标参数
+	requests = nil
+	for _, category := range []MessageFeedCategory{MessageFeedReply, MessageFeedAt, MessageFeedLike} {
+		if err = c.MarkMessageFeedRead(category); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, params := range requests {
+		if len(params.Get("id")) > 0 {
+			t.Fatalf("first page should not have cursor: %v", params)
+		}
+	}
+	if len(requests) != 3 {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+	if err = c.MarkMessageFeedRead("unknown"); err == nil {
+		t.Fatal("expected error for unknown category")
+	}
+}
+
+func TestMarkSystemNotifyRead(t *testing.T) {
+	var (
+		notifies []map[string]any
+		cursors  []string
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/sys-msg/query_user_notify": func(params url.Values) (any, error) {
+			if len(params.Get("cursor")) > 0 || params.Get("page_size") != "20" || params.Get("mobi_app") != "web" {
+				t.Errorf("unexpected params: %v", params)
+			}
+			return map[string]any{"system_notify_list": notifies}, nil
+		},
+		"/x/sys-msg/update_cursor": func(params url.Values) (any, error) {
+			if params.Get("csrf") != "csrf" || params.Get("mobi_app") != "web" {
+				t.Errorf("unexpected params: %v", params)
+			}
+			cursors = append(cursors, params.Get("cursor"))
+			return nil, nil
+		},
+	})
+
+	// 没有通知时不更新游标
+	if err := c.MarkMessageFeedRead(MessageFeedSysMsg); err != nil || len(cursors) != 0 {
+		t.Fatalf("unexpected result: %v, %v", err, cursors)
+	}
+	// 使用最新一条通知的游标
+	notifies = []map[string]any{{"id": 2, "cursor": 200}, {"id": 1, "cursor": 100}}
+	if err := c.MarkMessageFeedRead(MessageFeedSysMsg); err != nil || len(cursors) != 1 || cursors[0] != "200" {
+		t.Fatalf("unexpected result: %v, %v", err, cursors)
+	}
+}
@@ -0,0 +1,225 @@
+package bilibili
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// PrivateMessageEvent 收到的一条新私信
+type PrivateMessageEvent struct {
+	SessionType int     // 会话类型。1为用户，2为粉丝团
+	TalkerId    int     // 聊天对象的uid，粉丝团时为粉丝团id
+	Message     Message // 私信内容，可以通过 Message.Decode 解析
+	EInfos      []EInfo // 私信中用到的表情，可以用于 Message.RichText
+}
+
+// PrivateMessageSyncState 私信同步状态，保存在 Storage 中，键为 private_message_sync
+type PrivateMessageSyncState struct {
+	BeginTs int64            `json:"begin_ts"` // 已处理的最新会话时间。微秒级时间戳
+	Seqnos  map[string]int64 `json:"seqnos"`   // 会话 -> 已处理的最大消息序列号。键为 {session_type}:{talker_id}
+}
+
+// PrivateMessageSyncer 私信同步器。定时通过 GetNewPrivateMessageSessions 获取有变化的会话，
+// 只拉取每个会话中新增的消息，并把别人发来的消息按时间顺序产生事件。
+// 第一次同步时只记录状态，不会产生事件。状态会在事件发出之前保存，因此重启后不会重复通知
+type PrivateMessageSyncer struct {
+	client   *Client
+	storage  Storage
+	interval time.Duration
+	autoAck  bool
+	onError  func(err error)
+}
+
+// NewPrivateMessageSyncer 返回一个私信同步器，默认同步间隔为5秒，收到消息后自动设为已读，状态保存在内存中
+func (c *Client) NewPrivateMessageSyncer() *PrivateMessageSyncer {
+	return &PrivateMessageSyncer{
+		client:   c,
+		storage:  &MemoryStorage{data: make(map[string]any)},
+		interval: 5 * time.Second,
+		autoAck:  true,
+	}
+}
+
+// WithStorage 设置保存同步状态的存储，需要在重启后不重复通知时请使用持久化的存储
+func (s *PrivateMessageSyncer) WithStorage(storage Storage) *PrivateMessageSyncer {
+	s.storage = storage
+	return s
+}
+
+// WithInterval 设置同步间隔
+func (s *PrivateMessageSyncer) WithInterval(interval time.Duration) *PrivateMessageSyncer {
+	s.interval = interval
+	return s
+}
+
+// WithAutoAck 设置收到消息后是否自动通过 UpdatePrivateMessageAck 设为已读
+func (s *PrivateMessageSyncer) WithAutoAck(autoAck bool) *PrivateMessageSyncer {
+	s.autoAck = autoAck
+	return s
+}
+
+// WithErrorHandler 设置同步出错时的回调。出错不会中断同步，默认忽略错误
+func (s *PrivateMessageSyncer) WithErrorHandler(onError func(err error)) *PrivateMessageSyncer {
+	s.onError = onError
+	return s
+}
+
+// Run 持续同步私信，新消息会被串行传给 handler ，直到 ctx 被取消
+func (s *PrivateMessageSyncer) Run(ctx context.Context, handler func(*PrivateMessageEvent)) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		events, err := s.Sync()
+		if err != nil && s.onError != nil {
+			s.onError(err)
+		}
+		for _, event := range events {
+			handler(event)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+const privateMessageSyncKey = "private_message_sync"
+
+// Sync 立即同步一次，返回按时间顺序排列的新消息。
+// 中途出错时会返回已经同步完成的会话中的新消息以及错误，只有这些会话的进度会被保存和设为已读，其余会话下次同步时会重新拉取
+func (s *PrivateMessageSyncer) Sync() ([]*PrivateMessageEvent, error) {
+	selfUid := cast.ToInt(s.client.getCookie("DedeUserID"))
+	if selfUid == 0 {
+		return nil, errors.New("B站登录过期")
+	}
+	stored := s.loadState()
+	// 在副本上修改，出错时不会影响 Storage 中尚未同步完成的会话
+	state := &PrivateMessageSyncState{BeginTs: stored.BeginTs, Seqnos: maps.Clone(stored.Seqnos)}
+	inited := state.BeginTs > 0
+	sessions, err := s.client.GetNewPrivateMessageSessions(GetNewPrivateMessageSessionsParam{BeginTs: state.BeginTs, MobiApp: "web"})
+	if err != nil {
+		return nil, err
+	}
+	var events []*PrivateMessageEvent
+	beginTs := state.BeginTs
+	for _, session := range sessions.SessionList {
+		if err = s.syncSession(state, session, inited, selfUid, &events); err != nil {
+			break
+		}
+		beginTs = max(beginTs, session.SessionTs)
+	}
+	// 出错时不推进会话时间，下次同步时仍然能获取到未完成的会话
+	if err == nil {
+		state.BeginTs = beginTs
+	}
+	s.storage.Set(privateMessageSyncKey, state)
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].Message, events[j].Message
+		if a.Timestamp != b.Timestamp {
+			return a.Timestamp < b.Timestamp
+		}
+		return a.MsgSeqno < b.MsgSeqno
+	})
+	return events, err
+}
+
+// syncSession 同步一个会话，新消息追加到 events 中。只有成功时才会更新 state 中该会话的进度
+func (s *PrivateMessageSyncer) syncSession(state *PrivateMessageSyncState, session PrivateMessageSession, inited bool, selfUid int, events *[]*PrivateMessageEvent) error {
+	if session.SessionType != 1 && session.SessionType != 2 {
+		return nil
+	}
+	key := strconv.Itoa(session.SessionType) + ":" + strconv.FormatInt(session.TalkerId, 10)
+	seqno, ok := state.Seqnos[key]
+	if !inited || !ok {
+		// 第一次见到的会话，只记录当前的进度
+		if !inited || session.AckSeqno >= session.MaxSeqno {
+			state.Seqnos[key] = max(session.MaxSeqno, session.AckSeqno)
+			return nil
+		}
+		seqno = session.AckSeqno
+	}
+	if session.MaxSeqno <= seqno {
+		return nil
+	}
+	newEvents, maxSeqno, err := s.fetch(session, seqno, selfUid)
+	if err != nil {
+		return err
+	}
+	if s.autoAck && maxSeqno > session.AckSeqno {
+		if err = s.client.UpdatePrivateMessageAck(UpdatePrivateMessageAckParam{
+			TalkerId:    int(session.TalkerId),
+			SessionType: session.SessionType,
+			AckSeqno:    int(maxSeqno),
+			MobiApp:     "web",
+		}); err != nil {
+			return err
+		}
+	}
+	state.Seqnos[key] = max(maxSeqno, seqno)
+	*events = append(*events, newEvents...)
+	return nil
+}
+
+// fetch 拉取会话中序列号大于 seqno 的消息，返回别人发来的消息以及拉取到的最大序列号
+func (s *PrivateMessageSyncer) fetch(session PrivateMessageSession, seqno int64, selfUid int) ([]*PrivateMessageEvent, int64, error) {
+	var (
+		events   []*PrivateMessageEvent
+		maxSeqno = seqno
+		endSeqno int
+		seen     = make(map[int]struct{})
+	)
+	for {
+		records, err := s.client.GetPrivateMessageRecords(GetPrivateMessageRecordsParam{
+			TalkerId:    int(session.TalkerId),
+			SessionType: session.SessionType,
+			Size:        200,
+			MobiApp:     "web",
+			BeginSeqno:  int(seqno),
+			EndSeqno:    endSeqno,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		minSeqno := int64(-1)
+		for _, message := range records.Messages {
+			msgSeqno := int64(message.MsgSeqno)
+			if _, ok := seen[message.MsgSeqno]; ok || msgSeqno <= seqno {
+				continue
+			}
+			seen[message.MsgSeqno] = struct{}{}
+			maxSeqno = max(maxSeqno, msgSeqno)
+			if minSeqno < 0 || msgSeqno < minSeqno {
+				minSeqno = msgSeqno
+			}
+			if message.SenderUid == selfUid {
+				continue
+			}
+			events = append(events, &PrivateMessageEvent{
+				SessionType: session.SessionType,
+				TalkerId:    int(session.TalkerId),
+				Message:     message,
+				EInfos:      records.EInfos,
+			})
+		}
+		// 消息是从新到旧返回的，还有更早的新消息时继续向前翻页
+		if records.HasMore == 0 || minSeqno <= seqno+1 || (endSeqno > 0 && int(minSeqno) >= endSeqno) {
+			return events, maxSeqno, nil
+		}
+		endSeqno = int(minSeqno)
+	}
+}
+
+func (s *PrivateMessageSyncer) loadState() *PrivateMessageSyncState {
+	state := loadStorageState[PrivateMessageSyncState](s.storage, privateMessageSyncKey)
+	if state.Seqnos == nil {
+		state.Seqnos = make(map[string]int64)
+	}
+	return state
+}
@@ -0,0 +1,120 @@
+package bilibili
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestPrivateMessageSyncer(t *testing.T) {
+	var (
+		sessions []map[string]any
+		messages = make(map[string][]Message) // talker_id -> 从新到旧排列的消息
+		pageSize = 200
+		failed   string // 拉取该 talker_id 的消息时返回错误
+		acks     = make(map[string]string)
+		requests int
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/session_svr/v1/session_svr/new_sessions": func(url.Values) (any, error) {
+			return map[string]any{"session_list": sessions}, nil
+		},
+		"/svr_sync/v1/svr_sync/fetch_session_msgs": func(params url.Values) (any, error) {
+			requests++
+			talkerId := params.Get("talker_id")
+			if talkerId == failed {
+				return nil, Error{Code: -500, Message: "服务器错误"}
+			}
+			begin, _ := strconv.Atoi(params.Get("begin_seqno"))
+			end, _ := strconv.Atoi(params.Get("end_seqno"))
+			var result []Message
+			hasMore := 0
+			for _, m := range messages[talkerId] {
+				if m.MsgSeqno <= begin || (end > 0 && m.MsgSeqno >= end) {
+					continue
+				}
+				if len(result) == pageSize {
+					hasMore = 1
+					break
+				}
+				result = append(result, m)
+			}
+			return map[string]any{"messages": result, "has_more": hasMore}, nil
+		},
+		"/session_svr/v1/session_svr/update_ack": func(params url.Values) (any, error) {
+			acks[params.Get("talker_id")] = params.Get("ack_seqno")
+			return nil, nil
+		},
+	})
+	storage := &MemoryStorage{data: make(map[string]any)}
+	syncer := c.NewPrivateMessageSyncer().WithStorage(storage)
+	session := func(talkerId, ts, maxSeqno, ackSeqno int) map[string]any {
+		return map[string]any{"talker_id": talkerId, "session_type": 1, "session_ts": ts, "max_seqno": maxSeqno, "ack_seqno": ackSeqno}
+	}
+
+	// 第一次同步只记录进度
+	sessions = []map[string]any{session(2, 100, 5, 3), session(3, 100, 7, 7)}
+	if events, err := syncer.Sync(); err != nil || len(events) != 0 || requests != 0 {
+		t.Fatalf("unexpected first sync: %v, %v, %d", events, err, requests)
+	}
+	if state := syncer.loadState(); state.BeginTs != 100 || state.Seqnos["1:2"] != 5 || state.Seqnos["1:3"] != 7 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// 只产生别人发来的新消息，并设为已读
+	sessions = []map[string]any{session(2, 200, 7, 5)}
+	messages["2"] = []Message{
+		{SenderUid: 2, MsgSeqno: 7, Timestamp: 20},
+		{SenderUid: 1, MsgSeqno: 6, Timestamp: 10},
+		{SenderUid: 2, MsgSeqno: 5, Timestamp: 5},
+	}
+	events, err := syncer.Sync()
+	if err != nil || len(events) != 1 || events[0].Message.MsgSeqno != 7 || events[0].TalkerId != 2 || acks["2"] != "7" {
+		t.Fatalf("unexpected sync: %v, %v, %v", events, err, acks)
+	}
+
+	// 新消息超过一页时向前翻页，按时间顺序返回
+	pageSize = 2
+	sessions = []map[string]any{session(2, 300, 12, 7)}
+	messages["2"] = []Message{
+		{SenderUid: 2, MsgSeqno: 12, Timestamp: 50},
+		{SenderUid: 2, MsgSeqno: 11, Timestamp: 40},
+		{SenderUid: 2, MsgSeqno: 10, Timestamp: 40},
+		{SenderUid: 2, MsgSeqno: 9, Timestamp: 30},
+		{SenderUid: 2, MsgSeqno: 8, Timestamp: 30},
+		{SenderUid: 2, MsgSeqno: 7, Timestamp: 20},
+	}
+	requests = 0
+	events, err = syncer.Sync()
+	if err != nil || len(events) != 5 || requests != 3 {
+		t.Fatalf("unexpected paged sync: %v, %v, %d", events, err, requests)
+	}
+	for i, event := range events {
+		if event.Message.MsgSeqno != 8+i {
+			t.Fatalf("unexpected order: %v", events)
+		}
+	}
+
+	// 中途出错时返回已经同步完成的会话，出错的会话下次重新拉取
+	pageSize = 200
+	sessions = []map[string]any{session(2, 400, 13, 12), session(3, 410, 8, 7)}
+	messages["2"] = append([]Message{{SenderUid: 2, MsgSeqno: 13, Timestamp: 60}}, messages["2"]...)
+	messages["3"] = []Message{{SenderUid: 3, MsgSeqno: 8, Timestamp: 55}}
+	failed = "3"
+	events, err = syncer.Sync()
+	if err == nil || len(events) != 1 || events[0].Message.MsgSeqno != 13 || acks["3"] != "" {
+		t.Fatalf("unexpected failed sync: %v, %v, %v", events, err, acks)
+	}
+	if state := syncer.loadState(); state.BeginTs != 300 || state.Seqnos["1:2"] != 13 || state.Seqnos["1:3"] != 7 {
+		t.Fatalf("unexpected state after failure: %+v", state)
+	}
+
+	failed = ""
+	events, err = syncer.Sync()
+	if err != nil || len(events) != 1 || events[0].TalkerId != 3 || events[0].Message.MsgSeqno != 8 {
+		t.Fatalf("unexpected retry: %v, %v", events, err)
+	}
+	if state := syncer.loadState(); state.BeginTs != 410 || state.Seqnos["1:3"] != 8 {
+		t.Fatalf("unexpected state after retry: %+v", state)
+	}
+}
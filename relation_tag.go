@@ -0,0 +1,235 @@
+package bilibili
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// 特殊的关注分组 id
+const (
+	RelationTagSpecial = -10 // 特别关注
+	RelationTagDefault = 0   // 默认分组
+)
+
+type CreateRelationTagParam struct {
+	Tag string `json:"tag"` // 分组名称。最长16个字符
+}
+
+type CreateRelationTagResult struct {
+	Tagid int `json:"tagid"` // 新建的分组 id
+}
+
+// CreateRelationTag 新建关注分组
+func (c *Client) CreateRelationTag(param CreateRelationTagParam) (*CreateRelationTagResult, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tag/create"
+	)
+	return execute[*CreateRelationTagResult](c, method, url, param, fillCsrf(c))
+}
+
+type UpdateRelationTagParam struct {
+	Tagid int    `json:"tagid"` // 分组 id
+	Name  string `json:"name"`  // 新的分组名称。最长16个字符
+}
+
+// UpdateRelationTag 重命名关注分组
+func (c *Client) UpdateRelationTag(param UpdateRelationTagParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tag/update"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type DeleteRelationTagParam struct {
+	Tagid int `json:"tagid"` // 分组 id
+}
+
+// DeleteRelationTag 删除关注分组，分组中的用户会回到默认分组，不会被取关
+func (c *Client) DeleteRelationTag(param DeleteRelationTagParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tag/del"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type SetRelationTagUsersParam struct {
+	Fids   []int `json:"fids"`   // 目标用户 mid 列表。必须是已关注的用户
+	Tagids []int `json:"tagids"` // 分组 id 列表。为 0 时表示移回默认分组
+}
+
+// SetRelationTagUsers 把用户所在的分组设置为 Tagids ，会移出原有的其它分组
+func (c *Client) SetRelationTagUsers(param SetRelationTagUsersParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tags/addUsers"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// CopyRelationTagUsers 把用户加入 Tagids 中的分组，保留原有的分组
+func (c *Client) CopyRelationTagUsers(param SetRelationTagUsersParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tags/copyUsers"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type MoveRelationTagUsersParam struct {
+	BeforeTagids []int `json:"beforeTagids"` // 原分组 id 列表
+	AfterTagids  []int `json:"afterTagids"`  // 新分组 id 列表
+	Fids         []int `json:"fids"`         // 目标用户 mid 列表
+}
+
+// MoveRelationTagUsers 把用户从 BeforeTagids 中的分组移动到 AfterTagids 中的分组
+func (c *Client) MoveRelationTagUsers(param MoveRelationTagUsersParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tags/moveUsers"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type GetUserRelationTagsParam struct {
+	Fid int `json:"fid"` // 目标用户 mid
+}
+
+// GetUserRelationTags 查询已关注的用户所在的分组，返回分组 id 到分组名称的映射。在默认分组中时返回空
+func (c *Client) GetUserRelationTags(param GetUserRelationTagsParam) (map[int]string, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/relation/tag/user"
+	)
+	data, err := execute[json.RawMessage](c, method, url, param)
+	if err != nil {
+		return nil, err
+	}
+	return decodeIntKeyMap[string](data)
+}
+
+// RemoveRelationTagUsers 把用户移出 tagids 中的分组，保留其它的分组，不在任何分组中的用户会回到默认分组。
+// 需要先查询每个用户所在的分组，因此每个用户会多一次请求，所有请求之间至少间隔 interval 以免触发风控，ctx 被取消时返回错误。
+// 特别关注不受影响，请使用 DeleteSpecialFollow 取消
+func (c *Client) RemoveRelationTagUsers(ctx context.Context, fids, tagids []int, interval time.Duration) error {
+	p := pacer{interval: interval}
+	// 剩余分组相同的用户可以一起设置
+	groups := make(map[string][]int)
+	remaining := make(map[string][]int)
+	for _, fid := range fids {
+		if err := p.wait(ctx); err != nil {
+			return err
+		}
+		tags, err := c.GetUserRelationTags(GetUserRelationTagsParam{Fid: fid})
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(tagids, func(tagid int) bool {
+			_, ok := tags[tagid]
+			return ok && tagid != RelationTagSpecial
+		}) {
+			continue
+		}
+		rest := remainingRelationTags(tags, tagids)
+		key := joinInts(rest)
+		groups[key] = append(groups[key], fid)
+		remaining[key] = rest
+	}
+	for key, groupFids := range groups {
+		rest := remaining[key]
+		if len(rest) == 0 {
+			rest = []int{RelationTagDefault}
+		}
+		if err := p.wait(ctx); err != nil {
+			return err
+		}
+		if err := c.SetRelationTagUsers(SetRelationTagUsersParam{Fids: groupFids, Tagids: rest}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remainingRelationTags 返回 tags 中去掉 removed 和特别关注之后剩余的分组 id ，按从小到大排列。
+// 特别关注不能通过 SetRelationTagUsers 设置，因此不计入剩余的分组
+func remainingRelationTags(tags map[int]string, removed []int) []int {
+	rest := make([]int, 0, len(tags))
+	for tagid := range tags {
+		if tagid != RelationTagSpecial && !slices.Contains(removed, tagid) {
+			rest = append(rest, tagid)
+		}
+	}
+	sort.Ints(rest)
+	return rest
+}
+
+func joinInts(values []int) string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, strconv.Itoa(v))
+	}
+	return strings.Join(s, ",")
+}
+
+// GetAllRelationTagUsers 查询关注分组中的全部用户
+func (c *Client) GetAllRelationTagUsers(tagid int) ([]RelationUser, error) {
+	const ps = 50
+	var users []RelationUser
+	for pn := 1; ; pn++ {
+		page, err := c.GetRelationTagUsers(GetRelationTagUsersParam{Tagid: tagid, Ps: ps, Pn: pn})
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < ps {
+			return users, nil
+		}
+	}
+}
+
+type SpecialFollowParam struct {
+	Fid int `json:"fid"` // 目标用户 mid
+}
+
+// AddSpecialFollow 把已关注的用户设为特别关注
+func (c *Client) AddSpecialFollow(param SpecialFollowParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tag/special/add"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// DeleteSpecialFollow 取消特别关注，不会取关
+func (c *Client) DeleteSpecialFollow(param SpecialFollowParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/relation/tag/special/del"
+	)
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// GetSpecialFollows 查询所有特别关注的用户的 mid
+func (c *Client) GetSpecialFollows() ([]int, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/relation/tag/special"
+	)
+	return execute[[]int](c, method, url, nil)
+}
@@ -0,0 +1,141 @@
+package bilibili
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestRemainingRelationTags(t *testing.T) {
+	tags := map[int]string{3: "c", 1: "a", 2: "b"}
+	if rest := remainingRelationTags(tags, []int{2}); !slices.Equal(rest, []int{1, 3}) {
+		t.Fatalf("unexpected rest: %v", rest)
+	}
+	if rest := remainingRelationTags(nil, []int{2}); len(rest) != 0 {
+		t.Fatalf("unexpected rest: %v", rest)
+	}
+	if rest := remainingRelationTags(map[int]string{RelationTagSpecial: "特别关注", 1: "a", 2: "b"}, []int{2}); !slices.Equal(rest, []int{1}) {
+		t.Fatalf("special follow should be excluded: %v", rest)
+	}
+	if s := joinInts([]int{1, 3}); s != "1,3" {
+		t.Fatalf("unexpected join: %s", s)
+	}
+}
+
+func TestRelationTagUsers(t *testing.T) {
+	requests := make(map[string][]url.Values)
+	record := func(path string, data any) stubHandler {
+		return func(params url.Values) (any, error) {
+			if params.Get("csrf") != "csrf" {
+				t.Errorf("%s: missing csrf", path)
+			}
+			requests[path] = append(requests[path], params)
+			return data, nil
+		}
+	}
+	userTags := map[string]map[string]string{
+		"2": {"-10": "特别关注", "3": "c", "4": "d"},
+		"3": {"3": "c"},
+		"4": {"-10": "特别关注", "4": "d"},
+		"5": {"3": "c", "4": "d"},
+	}
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/relation/tag/create":     record("create", map[string]any{"tagid": 7}),
+		"/x/relation/tag/update":     record("update", nil),
+		"/x/relation/tag/del":        record("del", nil),
+		"/x/relation/tags/copyUsers": record("copyUsers", nil),
+		"/x/relation/tags/addUsers":  record("addUsers", nil),
+		"/x/relation/tags/moveUsers": record("moveUsers", nil),
+		"/x/relation/tag/user": func(params url.Values) (any, error) {
+			if tags, ok := userTags[params.Get("fid")]; ok {
+				return tags, nil
+			}
+			return []any{}, nil
+		},
+	})
+
+	result, err := c.CreateRelationTag(CreateRelationTagParam{Tag: "分组"})
+	if err != nil || result.Tagid != 7 || requests["create"][0].Get("tag") != "分组" {
+		t.Fatalf("unexpected create: %v, %v, %v", result, err, requests["create"])
+	}
+	if err = c.UpdateRelationTag(UpdateRelationTagParam{Tagid: 7, Name: "新分组"}); err != nil {
+		t.Fatal(err)
+	}
+	if params := requests["update"][0]; params.Get("tagid") != "7" || params.Get("name") != "新分组" {
+		t.Fatalf("unexpected update params: %v", params)
+	}
+	if err = c.DeleteRelationTag(DeleteRelationTagParam{Tagid: 7}); err != nil || requests["del"][0].Get("tagid") != "7" {
+		t.Fatalf("unexpected delete: %v, %v", err, requests["del"])
+	}
+	// 列表参数以逗号连接
+	if err = c.SetRelationTagUsers(SetRelationTagUsersParam{Fids: []int{2, 3}, Tagids: []int{3, 4}}); err != nil {
+		t.Fatal(err)
+	}
+	if params := requests["addUsers"][0]; params.Get("fids") != "2,3" || params.Get("tagids") != "3,4" {
+		t.Fatalf("unexpected addUsers params: %v", params)
+	}
+	if err = c.CopyRelationTagUsers(SetRelationTagUsersParam{Fids: []int{2}, Tagids: []int{3, 4, 5}}); err != nil {
+		t.Fatal(err)
+	}
+	if params := requests["copyUsers"][0]; params.Get("fids") != "2" || params.Get("tagids") != "3,4,5" {
+		t.Fatalf("unexpected copyUsers params: %v", params)
+	}
+	err = c.MoveRelationTagUsers(MoveRelationTagUsersParam{BeforeTagids: []int{3}, AfterTagids: []int{4, 5}, Fids: []int{2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params := requests["moveUsers"][0]; params.Get("beforeTagids") != "3" || params.Get("afterTagids") != "4,5" || params.Get("fids") != "2" {
+		t.Fatalf("unexpected moveUsers params: %v", params)
+	}
+
+	// 移出分组3：剩余分组相同的用户一起设置，不在分组3中的用户不设置，特别关注不计入剩余分组
+	requests["addUsers"] = nil
+	if err = c.RemoveRelationTagUsers(context.Background(), []int{2, 3, 4, 5, 6}, []int{3}, 0); err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, params := range requests["addUsers"] {
+		got[params.Get("tagids")] = params.Get("fids")
+	}
+	if len(got) != 2 || got["4"] != "2,5" || got["0"] != "3" {
+		t.Fatalf("unexpected remove: %v", requests["addUsers"])
+	}
+}
+
+func TestGetAllRelationTagUsers(t *testing.T) {
+	var (
+		total int
+		pages []string
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/relation/tag": func(params url.Values) (any, error) {
+			if params.Get("tagid") != "3" || params.Get("ps") != "50" {
+				t.Errorf("unexpected params: %v", params)
+			}
+			pages = append(pages, params.Get("pn"))
+			pn, _ := strconv.Atoi(params.Get("pn"))
+			users := make([]map[string]any, 0, 50)
+			for mid := (pn-1)*50 + 1; mid <= min(pn*50, total); mid++ {
+				users = append(users, map[string]any{"mid": mid})
+			}
+			return users, nil
+		},
+	})
+	for _, tc := range []struct {
+		total, pages int
+	}{{120, 3}, {100, 3}, {0, 1}} {
+		total, pages = tc.total, nil
+		users, err := c.GetAllRelationTagUsers(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != tc.total || len(pages) != tc.pages || pages[0] != "1" {
+			t.Fatalf("total %d: unexpected result: %d users, pages %v", tc.total, len(users), pages)
+		}
+		if tc.total > 0 && users[tc.total-1].Mid != tc.total {
+			t.Fatalf("unexpected last user: %+v", users[tc.total-1])
+		}
+	}
+}
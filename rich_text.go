@@ -0,0 +1,320 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+type RichTextSegmentType int
+
+const (
+	RichTextSegmentText      RichTextSegmentType = iota // 普通文本
+	RichTextSegmentEmote                                // 表情
+	RichTextSegmentMention                              // @用户
+	RichTextSegmentLink                                 // 跳转链接
+	RichTextSegmentHashtag                              // 话题
+	RichTextSegmentTimestamp                            // 视频时间戳
+)
+
+// RichTextSegment 富文本中的一段内容
+type RichTextSegment struct {
+	Type    RichTextSegmentType // 片段类型
+	Text    string              // 原始文本。例如 [doge]、@昵称、#话题#、01:23
+	Url     string              // 表情的图片url，或者@用户、跳转链接、话题、时间戳对应的跳转url
+	Title   string              // 跳转链接的标题，仅用于跳转链接
+	Mid     int                 // 用户mid，仅用于@用户
+	Seconds int                 // 时间戳对应的秒数，仅用于视频时间戳
+}
+
+// RichText 由多个片段组成的富文本，可以渲染为纯文本、Markdown和HTML
+type RichText []RichTextSegment
+
+// RichTextLink 富文本中需要高亮的跳转链接
+type RichTextLink struct {
+	Title string // 链接标题
+	Url   string // 跳转url
+}
+
+// RichTextEntities 解析富文本时需要识别的内容
+type RichTextEntities struct {
+	Emotes    map[string]string       // 表情文本 -> 表情图片url。例如 [doge]
+	Mentions  map[string]int          // 用户昵称（不含@） -> 用户mid
+	Links     map[string]RichTextLink // 需要高亮的文本 -> 跳转链接
+	Hashtag   bool                    // 是否识别 #话题#
+	Timestamp bool                    // 是否识别视频时间戳，例如 01:23、1:02:03
+	VideoUrl  string                  // 时间戳跳转的视频url，为空则时间戳没有跳转url
+}
+
+var (
+	regRichTextHashtag   = regexp.MustCompile(`#[^#\s]{1,32}#`)
+	regRichTextTimestamp = regexp.MustCompile(`\b(?:(\d{1,2}):)?(\d{1,2}):(\d{2})\b`)
+)
+
+// ParseRichText 按照 entities 将文本拆分为富文本片段
+func ParseRichText(text string, entities RichTextEntities) RichText {
+	type candidate struct {
+		start, end int
+		segment    RichTextSegment
+	}
+	candidates := make([]candidate, 0, 8)
+	findAll := func(sub string, segment RichTextSegment) {
+		if len(sub) == 0 {
+			return
+		}
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], sub)
+			if i < 0 {
+				return
+			}
+			start := offset + i
+			candidates = append(candidates, candidate{start: start, end: start + len(sub), segment: segment})
+			offset = start + len(sub)
+		}
+	}
+	for emote, u := range entities.Emotes {
+		findAll(emote, RichTextSegment{Type: RichTextSegmentEmote, Text: emote, Url: u})
+	}
+	for name, mid := range entities.Mentions {
+		findAll("@"+name, RichTextSegment{Type: RichTextSegmentMention, Text: "@" + name, Url: "https://space.bilibili.com/" + strconv.Itoa(mid), Mid: mid})
+	}
+	for keyword, link := range entities.Links {
+		findAll(keyword, RichTextSegment{Type: RichTextSegmentLink, Text: keyword, Url: link.Url, Title: link.Title})
+	}
+	if entities.Hashtag {
+		for _, loc := range regRichTextHashtag.FindAllStringIndex(text, -1) {
+			tag := text[loc[0]:loc[1]]
+			candidates = append(candidates, candidate{start: loc[0], end: loc[1], segment: RichTextSegment{
+				Type: RichTextSegmentHashtag,
+				Text: tag,
+				Url:  "https://search.bilibili.com/all?keyword=" + url.QueryEscape(strings.Trim(tag, "#")),
+			}})
+		}
+	}
+	if entities.Timestamp {
+		for _, loc := range regRichTextTimestamp.FindAllStringSubmatchIndex(text, -1) {
+			seconds := cast.ToInt(text[loc[4]:loc[5]])*60 + cast.ToInt(text[loc[6]:loc[7]])
+			if loc[2] >= 0 {
+				seconds += cast.ToInt(text[loc[2]:loc[3]]) * 3600
+			}
+			segment := RichTextSegment{Type: RichTextSegmentTimestamp, Text: text[loc[0]:loc[1]], Seconds: seconds}
+			if len(entities.VideoUrl) > 0 {
+				segment.Url = entities.VideoUrl + "?t=" + strconv.Itoa(seconds)
+			}
+			candidates = append(candidates, candidate{start: loc[0], end: loc[1], segment: segment})
+		}
+	}
+	// 靠前的优先，起点相同时较长的优先，重叠的部分舍弃
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].start != candidates[j].start {
+			return candidates[i].start < candidates[j].start
+		}
+		return candidates[i].end > candidates[j].end
+	})
+	result := make(RichText, 0, len(candidates)*2+1)
+	pos := 0
+	for _, c := range candidates {
+		if c.start < pos {
+			continue
+		}
+		if c.start > pos {
+			result = append(result, RichTextSegment{Type: RichTextSegmentText, Text: text[pos:c.start]})
+		}
+		result = append(result, c.segment)
+		pos = c.end
+	}
+	if pos < len(text) {
+		result = append(result, RichTextSegment{Type: RichTextSegmentText, Text: text[pos:]})
+	}
+	return result
+}
+
+// PlainText 渲染为纯文本，表情保留为 [doge] 的形式
+func (r RichText) PlainText() string {
+	var sb strings.Builder
+	for _, segment := range r {
+		sb.WriteString(segment.Text)
+	}
+	return sb.String()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `(`, `\(`, `)`, `\)`,
+	`#`, `\#`, `~`, `\~`, `|`, `\|`, `<`, `\<`, `>`, `\>`, "\n", "  \n",
+)
+
+// Markdown 渲染为Markdown，不安全的链接会被渲染为普通文字
+func (r RichText) Markdown() string {
+	var sb strings.Builder
+	for _, segment := range r {
+		text := markdownEscaper.Replace(segment.Text)
+		u := markdownUrl(segment.Url)
+		switch {
+		case len(u) == 0:
+			sb.WriteString(text)
+		case segment.Type == RichTextSegmentEmote:
+			sb.WriteString("![" + text + "](" + u + ")")
+		case segment.Type != RichTextSegmentText:
+			sb.WriteString("[" + text + "](" + u + ")")
+		default:
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// HTML 渲染为HTML片段，换行会被转换为 <br>
+func (r RichText) HTML() string {
+	var sb strings.Builder
+	for _, segment := range r {
+		text := strings.ReplaceAll(html.EscapeString(segment.Text), "\n", "<br>")
+		u := html.EscapeString(safeUrl(segment.Url))
+		switch {
+		case len(u) == 0:
+			sb.WriteString(text)
+		case segment.Type == RichTextSegmentEmote:
+			sb.WriteString(`<img class="bili-emote" src="` + u + `" alt="` + text + `">`)
+		case segment.Type != RichTextSegmentText:
+			sb.WriteString(`<a href="` + u + `"`)
+			if len(segment.Title) > 0 {
+				sb.WriteString(` title="` + html.EscapeString(segment.Title) + `"`)
+			}
+			sb.WriteString(`>` + text + `</a>`)
+		default:
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// safeUrl 只保留 http 、 https 以及没有协议的链接，其它协议（例如 javascript: ）的链接返回空字符串
+func safeUrl(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https":
+		return u
+	}
+	return ""
+}
+
+// markdownUrlEscaper 转义会破坏Markdown链接语法的字符
+var markdownUrlEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+
+// markdownUrl 返回可以放在Markdown链接中的url，不安全的链接见 safeUrl ，返回空字符串
+func markdownUrl(u string) string {
+	return markdownUrlEscaper.Replace(safeUrl(u))
+}
+
+type CommentEmote struct {
+	Id        int      `json:"id"`         // 表情 id
+	PackageId int      `json:"package_id"` // 表情包 id
+	State     int      `json:"state"`      // 0
+	Type      int      `json:"type"`       // 表情类型。1：免费。2：会员专属。3：购买所得。4：颜文字
+	Attr      int      `json:"attr"`       // (?)
+	Text      string   `json:"text"`       // 表情转义符
+	Url       string   `json:"url"`        // 表情图片 url
+	Meta      struct { // 属性信息
+		Size int `json:"size"` // 表情尺寸信息。1：小。2：大
+	} `json:"meta"`
+	Mtime     int    `json:"mtime"`      // 表情创建时间。时间戳
+	JumpTitle string `json:"jump_title"` // 表情名称
+}
+
+type CommentJumpUrl struct {
+	Title        string `json:"title"`          // 标题
+	State        int    `json:"state"`          // (?)
+	PrefixIcon   string `json:"prefix_icon"`    // 图标 url
+	AppUrlSchema string `json:"app_url_schema"` // APP 跳转 uri
+	AppName      string `json:"app_name"`       // APP 名称
+	PcUrl        string `json:"pc_url"`         // 网页端跳转 url
+	ClickReport  string `json:"click_report"`   // 上报 id
+	IsHalfScreen bool   `json:"is_half_screen"` // (?)
+}
+
+// Emotes 将 Emote 字段解析为 表情转义符 -> 表情 的映射
+func (content *CommentContent) Emotes() map[string]CommentEmote {
+	var emotes map[string]CommentEmote
+	if buf, err := json.Marshal(content.Emote); err == nil {
+		_ = json.Unmarshal(buf, &emotes)
+	}
+	return emotes
+}
+
+// JumpUrls 将 JumpUrl 字段解析为 高亮文本 -> 跳转信息 的映射
+func (content *CommentContent) JumpUrls() map[string]CommentJumpUrl {
+	var jumpUrls map[string]CommentJumpUrl
+	if buf, err := json.Marshal(content.JumpUrl); err == nil {
+		_ = json.Unmarshal(buf, &jumpUrls)
+	}
+	return jumpUrls
+}
+
+// Entities 获取解析富文本所需的表情、@用户和跳转链接信息，不识别话题和时间戳
+func (content *CommentContent) Entities() RichTextEntities {
+	entities := RichTextEntities{
+		Emotes:   make(map[string]string),
+		Mentions: make(map[string]int, len(content.Members)),
+		Links:    make(map[string]RichTextLink),
+	}
+	for text, emote := range content.Emotes() {
+		entities.Emotes[text] = emote.Url
+	}
+	for _, member := range content.Members {
+		entities.Mentions[member.Uname] = cast.ToInt(member.Mid)
+	}
+	for text, jumpUrl := range content.JumpUrls() {
+		entities.Links[text] = RichTextLink{Title: jumpUrl.Title, Url: resolveJumpUrl(text, jumpUrl)}
+	}
+	return entities
+}
+
+// resolveJumpUrl 确定高亮文本的跳转目标。没有网页端链接的高亮文本通常是视频号或者搜索关键词
+func resolveJumpUrl(text string, jumpUrl CommentJumpUrl) string {
+	switch {
+	case len(jumpUrl.PcUrl) > 0:
+		return jumpUrl.PcUrl
+	case strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://"):
+		return text
+	case regBv.MatchString(text):
+		return "https://www.bilibili.com/video/" + regBv.FindString(text)
+	default:
+		return "https://search.bilibili.com/all?keyword=" + url.QueryEscape(text)
+	}
+}
+
+// RichText 将评论内容解析为富文本。视频评论区（Type为1）中的时间戳会被识别为跳转到视频对应时间的链接
+func (c *Comment) RichText() RichText {
+	entities := c.Content.Entities()
+	entities.Hashtag = true
+	if c.Type == 1 {
+		entities.Timestamp = true
+		entities.VideoUrl = "https://www.bilibili.com/video/" + Av2Bv(c.Oid)
+	}
+	return ParseRichText(c.Content.Message, entities)
+}
+
+// RichText 将文字私信解析为富文本，eInfos 为 PrivateMessageRecords.EInfos ，用于识别表情。非文字私信返回 nil
+func (m *Message) RichText(eInfos []EInfo) RichText {
+	decoded, err := m.Decode()
+	if err != nil {
+		return nil
+	}
+	content, ok := decoded.(*TextContent)
+	if !ok {
+		return nil
+	}
+	entities := RichTextEntities{Emotes: make(map[string]string, len(eInfos))}
+	for _, eInfo := range eInfos {
+		entities.Emotes[eInfo.Text] = eInfo.Uri
+	}
+	return ParseRichText(content.Content, entities)
+}
@@ -0,0 +1,59 @@
+package bilibili
+
+import "testing"
+
+func TestRichText(t *testing.T) {
+	c := &Comment{
+		Type: 1,
+		Oid:  111298867365120,
+		Content: CommentContent{
+			Message: "@小明 看1:05[doge]，#测试#\n链接BV1L9Uoa9EUx",
+			Members: []Member{{Mid: "123", Uname: "小明"}},
+			Emote:   map[string]any{"[doge]": map[string]any{"id": 1, "text": "[doge]", "url": "https://i0.hdslb.com/doge.png"}},
+			JumpUrl: map[string]any{"BV1L9Uoa9EUx": map[string]any{"title": "视频"}},
+		},
+	}
+	r := c.RichText()
+	types := []RichTextSegmentType{
+		RichTextSegmentMention, RichTextSegmentText, RichTextSegmentTimestamp, RichTextSegmentEmote,
+		RichTextSegmentText, RichTextSegmentHashtag, RichTextSegmentText, RichTextSegmentLink,
+	}
+	if len(r) != len(types) {
+		t.Fatal("segment count not correct ", r)
+	}
+	for i, typ := range types {
+		if r[i].Type != typ {
+			t.Fatal("segment type not correct ", i, r[i])
+		}
+	}
+	if r[0].Mid != 123 || r[2].Seconds != 65 || r[2].Url != "https://www.bilibili.com/video/BV1L9Uoa9EUx?t=65" {
+		t.Fatal("segment content not correct ", r)
+	}
+	if r.PlainText() != c.Content.Message {
+		t.Fatal("plain text not correct ", r.PlainText())
+	}
+	if md := r.Markdown(); md != "[@小明](https://space.bilibili.com/123) 看[1:05](https://www.bilibili.com/video/BV1L9Uoa9EUx?t=65)"+
+		"![\\[doge\\]](https://i0.hdslb.com/doge.png)，[\\#测试\\#](https://search.bilibili.com/all?keyword=%E6%B5%8B%E8%AF%95)  \n"+
+		"链接[BV1L9Uoa9EUx](https://www.bilibili.com/video/BV1L9Uoa9EUx)" {
+		t.Fatal("markdown not correct ", md)
+	}
+	if h := r.HTML(); h != `<a href="https://space.bilibili.com/123">@小明</a> 看<a href="https://www.bilibili.com/video/BV1L9Uoa9EUx?t=65">1:05</a>`+
+		`<img class="bili-emote" src="https://i0.hdslb.com/doge.png" alt="[doge]">，<a href="https://search.bilibili.com/all?keyword=%E6%B5%8B%E8%AF%95">#测试#</a><br>`+
+		`链接<a href="https://www.bilibili.com/video/BV1L9Uoa9EUx" title="视频">BV1L9Uoa9EUx</a>` {
+		t.Fatal("html not correct ", h)
+	}
+}
+
+func TestRichTextUnsafeUrl(t *testing.T) {
+	r := RichText{
+		{Type: RichTextSegmentLink, Text: "脚本", Url: "javascript:alert(1)"},
+		{Type: RichTextSegmentEmote, Text: "[doge]", Url: "data:image/png;base64,AAAA"},
+		{Type: RichTextSegmentLink, Text: "链接", Url: "https://example.com/a b(1)"},
+	}
+	if md := r.Markdown(); md != `脚本\[doge\][链接](https://example.com/a%20b%281%29)` {
+		t.Fatal("markdown not correct ", md)
+	}
+	if h := r.HTML(); h != `脚本[doge]<a href="https://example.com/a b(1)">链接</a>` {
+		t.Fatal("html not correct ", h)
+	}
+}
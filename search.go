@@ -1,6 +1,11 @@
 package bilibili
 
-import "github.com/go-resty/resty/v2"
+import (
+	"encoding/json"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
 
 // https://socialsisteryi.github.io/bilibili-API-collect/docs/search/*
 
@@ -112,3 +117,118 @@ func (c *Client) IntergratedSearch(param SearchParam) (*SearchRespData, error) {
 	)
 	return execute[*SearchRespData](c, method, url, param, fillWbiHandler(c.wbi, c.GetCookies()))
 }
+
+type SearchSuggestParam struct {
+	Term      string `json:"term"`                                          // 需要补全的关键词
+	MainVer   string `json:"main_ver" request:"query,default=v1"`           // v1
+	Highlight string `json:"highlight,omitempty" request:"query,omitempty"` // 需要高亮的关键词。默认与 Term 相同
+}
+
+type SearchSuggestItem struct {
+	Value string `json:"value"` // 补全后的关键词
+	Term  string `json:"term"`  // 补全后的关键词
+	Ref   int    `json:"ref"`   // 作用尚不明确
+	Name  string `json:"name"`  // 带有高亮的关键词。匹配部分使用 <em class="suggest_high_light"> 标签包裹
+	Spid  int    `json:"spid"`  // 作用尚不明确
+	Type  string `json:"type"`  // 作用尚不明确
+}
+
+// GetSearchSuggest 获取搜索建议关键词（自动补全）
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/suggest.md
+func (c *Client) GetSearchSuggest(param SearchSuggestParam) ([]SearchSuggestItem, error) {
+	const url = "https://s.search.bilibili.com/main/suggest"
+	var result struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := executeSearch(c, url, param, &result); err != nil {
+		return nil, err
+	}
+	// 没有结果时 result 为空对象或者空数组
+	var data struct {
+		Tag []SearchSuggestItem `json:"tag"`
+	}
+	if len(result.Result) > 0 && result.Result[0] == '{' {
+		if err := json.Unmarshal(result.Result, &data); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return data.Tag, nil
+}
+
+type SearchDefaultWord struct {
+	Seid      string `json:"seid"`       // 搜索id
+	Id        int    `json:"id"`         // 默认搜索id
+	Type      int    `json:"type"`       // 作用尚不明确
+	Name      string `json:"name"`       // 搜索框中显示的内容
+	ShowName  string `json:"show_name"`  // 作用尚不明确
+	GotoType  int    `json:"goto_type"`  // 跳转类型。0：搜索。1：视频
+	GotoValue string `json:"goto_value"` // 搜索的关键词或者跳转的目标
+	Url       string `json:"url"`        // 跳转url
+}
+
+// GetSearchDefaultWord 获取搜索框中的默认搜索内容
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_default.md
+func (c *Client) GetSearchDefaultWord() (*SearchDefaultWord, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/web-interface/wbi/search/default"
+	)
+	return execute[*SearchDefaultWord](c, method, url, nil, fillWbiHandler(c.wbi, c.GetCookies()))
+}
+
+type HotSearchItem struct {
+	Position   int    `json:"position"`    // 排名，从1开始
+	Keyword    string `json:"keyword"`     // 关键词
+	ShowName   string `json:"show_name"`   // 显示的文字
+	WordType   int    `json:"word_type"`   // 条目类型。4：新。5：热。7：直播中。8：普通。9：梗。11：话题。12：独家
+	Icon       string `json:"icon"`        // 图标url。没有图标时为空
+	HotId      int    `json:"hot_id"`      // 条目id
+	HeatScore  int    `json:"heat_score"`  // 热度
+	GotoType   int    `json:"goto_type"`   // 跳转类型
+	GotoValue  string `json:"goto_value"`  // 跳转目标
+	CallReason int    `json:"call_reason"` // 作用尚不明确
+}
+
+type HotSearch struct {
+	Seid       string          `json:"seid"`        // 搜索id
+	Timestamp  int             `json:"timestamp"`   // 更新时间。时间戳
+	TotalCount int             `json:"total_count"` // 条目总数
+	List       []HotSearchItem `json:"list"`        // 热搜列表，按照排名排序
+}
+
+// GetHotSearch 获取热搜列表
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/hot.md
+func (c *Client) GetHotSearch() (*HotSearch, error) {
+	const url = "https://s.search.bilibili.com/main/hotword"
+	result := &HotSearch{}
+	if err := executeSearch(c, url, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// executeSearch 请求 s.search.bilibili.com 下的接口。这些接口不是 {code,message,data} 的格式，数据直接放在最外层
+func executeSearch(c *Client, url string, in, out any) error {
+	r := c.resty.R()
+	if err := withParams(r, in); err != nil {
+		return err
+	}
+	resp, err := r.Get(url)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if resp.StatusCode() != 200 {
+		return errors.Errorf("status code: %d", resp.StatusCode())
+	}
+	var cr commonResp
+	if err = json.Unmarshal(resp.Body(), &cr); err != nil {
+		return errors.WithStack(err)
+	}
+	if cr.Code != 0 {
+		return errors.WithStack(Error{Code: cr.Code, Message: cr.Message})
+	}
+	return errors.WithStack(json.Unmarshal(resp.Body(), out))
+}
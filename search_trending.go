@@ -0,0 +1,135 @@
+package bilibili
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HotSearchTrend 热搜条目排名的变化
+type HotSearchTrend struct {
+	Item        HotSearchItem // 当前的热搜条目
+	OldPosition int           // 上一次快照中的排名
+}
+
+// HotSearchChange 两次热搜快照之间的变化
+type HotSearchChange struct {
+	Time    time.Time        // 本次快照的时间
+	List    []HotSearchItem  // 本次快照的热搜列表
+	Rising  []HotSearchTrend // 排名上升的条目
+	Falling []HotSearchTrend // 排名下降的条目
+	New     []HotSearchItem  // 新上榜的条目
+	Dropped []HotSearchItem  // 跌出榜单的条目，为上一次快照中的内容
+}
+
+// Changed 返回是否有任何变化
+func (c *HotSearchChange) Changed() bool {
+	return len(c.Rising) > 0 || len(c.Falling) > 0 || len(c.New) > 0 || len(c.Dropped) > 0
+}
+
+// HotSearchTracker 热搜追踪器。定时通过 GetHotSearch 获取热搜列表，与上一次快照对比后报告变化。
+// 条目按照 Keyword 区分，第一次获取时只记录快照
+type HotSearchTracker struct {
+	client   *Client
+	interval time.Duration
+	onError  func(err error)
+
+	mu   sync.Mutex
+	last []HotSearchItem
+}
+
+// NewHotSearchTracker 返回一个热搜追踪器，默认间隔为5分钟
+func (c *Client) NewHotSearchTracker() *HotSearchTracker {
+	return &HotSearchTracker{
+		client:   c,
+		interval: 5 * time.Minute,
+	}
+}
+
+// WithInterval 设置获取热搜的间隔
+func (t *HotSearchTracker) WithInterval(interval time.Duration) *HotSearchTracker {
+	t.interval = interval
+	return t
+}
+
+// WithErrorHandler 设置获取热搜出错时的回调。出错不会中断追踪，默认忽略错误
+func (t *HotSearchTracker) WithErrorHandler(onError func(err error)) *HotSearchTracker {
+	t.onError = onError
+	return t
+}
+
+// Last 返回上一次的热搜快照
+func (t *HotSearchTracker) Last() []HotSearchItem {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+// Run 持续追踪热搜，有变化时会把变化串行传给 handler ，直到 ctx 被取消
+func (t *HotSearchTracker) Run(ctx context.Context, handler func(*HotSearchChange)) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		change, err := t.Check()
+		if err != nil {
+			if t.onError != nil {
+				t.onError(err)
+			}
+		} else if change != nil && change.Changed() {
+			handler(change)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check 立即获取一次热搜，返回与上一次快照相比的变化。第一次获取时返回 nil
+func (t *HotSearchTracker) Check() (*HotSearchChange, error) {
+	hot, err := t.client.GetHotSearch()
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	last := t.last
+	t.last = hot.List
+	if last == nil {
+		return nil, nil //nolint:nilnil
+	}
+	change := diffHotSearch(last, hot.List)
+	change.Time = time.Now()
+	return change, nil
+}
+
+// diffHotSearch 比较两次热搜快照
+func diffHotSearch(last, current []HotSearchItem) *HotSearchChange {
+	change := &HotSearchChange{List: current}
+	positions := make(map[string]int, len(last))
+	for _, item := range last {
+		positions[item.Keyword] = item.Position
+	}
+	seen := make(map[string]struct{}, len(current))
+	for _, item := range current {
+		seen[item.Keyword] = struct{}{}
+		oldPosition, ok := positions[item.Keyword]
+		switch {
+		case !ok:
+			change.New = append(change.New, item)
+		case item.Position < oldPosition:
+			change.Rising = append(change.Rising, HotSearchTrend{Item: item, OldPosition: oldPosition})
+		case item.Position > oldPosition:
+			change.Falling = append(change.Falling, HotSearchTrend{Item: item, OldPosition: oldPosition})
+		}
+	}
+	for _, item := range last {
+		if _, ok := seen[item.Keyword]; !ok {
+			change.Dropped = append(change.Dropped, item)
+		}
+	}
+	return change
+}
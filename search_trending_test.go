@@ -0,0 +1,37 @@
+package bilibili
+
+import "testing"
+
+func TestDiffHotSearch(t *testing.T) {
+	last := []HotSearchItem{
+		{Position: 1, Keyword: "a"},
+		{Position: 2, Keyword: "b"},
+		{Position: 3, Keyword: "c"},
+		{Position: 4, Keyword: "d"},
+	}
+	current := []HotSearchItem{
+		{Position: 1, Keyword: "b"},
+		{Position: 2, Keyword: "a"},
+		{Position: 3, Keyword: "c"},
+		{Position: 4, Keyword: "e"},
+	}
+	change := diffHotSearch(last, current)
+	if !change.Changed() {
+		t.Fatal("expected changes")
+	}
+	if len(change.Rising) != 1 || change.Rising[0].Item.Keyword != "b" || change.Rising[0].OldPosition != 2 {
+		t.Fatalf("unexpected rising: %+v", change.Rising)
+	}
+	if len(change.Falling) != 1 || change.Falling[0].Item.Keyword != "a" || change.Falling[0].OldPosition != 1 {
+		t.Fatalf("unexpected falling: %+v", change.Falling)
+	}
+	if len(change.New) != 1 || change.New[0].Keyword != "e" {
+		t.Fatalf("unexpected new: %+v", change.New)
+	}
+	if len(change.Dropped) != 1 || change.Dropped[0].Keyword != "d" {
+		t.Fatalf("unexpected dropped: %+v", change.Dropped)
+	}
+	if diffHotSearch(current, current).Changed() {
+		t.Fatal("expected no changes")
+	}
+}
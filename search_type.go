@@ -0,0 +1,340 @@
+package bilibili
+
+import (
+	"html"
+	"iter"
+	"regexp"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// SearchType 分类搜索的目标类型
+type SearchType string
+
+const (
+	SearchTypeVideo    SearchType = "video"         // 视频
+	SearchTypeUser     SearchType = "bili_user"     // 用户
+	SearchTypeArticle  SearchType = "article"       // 专栏
+	SearchTypeLiveRoom SearchType = "live_room"     // 直播间
+	SearchTypeBangumi  SearchType = "media_bangumi" // 番剧
+	SearchTypeMovie    SearchType = "media_ft"      // 影视
+)
+
+// 视频时长筛选，见 SearchTypeParam.Duration
+const (
+	SearchDurationAll     = 0 // 全部时长
+	SearchDurationUnder10 = 1 // 10分钟以下
+	SearchDuration10To30  = 2 // 10-30分钟
+	SearchDuration30To60  = 3 // 30-60分钟
+	SearchDurationOver60  = 4 // 60分钟以上
+)
+
+// searchMaxPages B站分类搜索最多只能翻到第50页
+const searchMaxPages = 50
+
+type SearchTypeParam struct {
+	SearchType SearchType `json:"search_type"`                                     // 搜索目标类型。调用 SearchVideo 等方法时会自动填写
+	Keyword    string     `json:"keyword"`                                         // 需要搜索的关键词
+	Order      string     `json:"order,omitempty" request:"query,omitempty"`       // 结果排序方式。视频：totalrank、click、pubdate、dm、stow、scores。专栏：totalrank、click、pubdate、attention、scores。直播间：online、live_time。用户：0、fans、level
+	OrderSort  int        `json:"order_sort,omitempty" request:"query,omitempty"`  // 用户粉丝数及等级排序顺序。0：由高到低。1：由低到高。仅搜索用户时有效
+	UserType   int        `json:"user_type,omitempty" request:"query,omitempty"`   // 用户分类筛选。0：全部用户。1：UP主。2：普通用户。3：认证用户。仅搜索用户时有效
+	Duration   int        `json:"duration,omitempty" request:"query,omitempty"`    // 视频时长筛选，见 SearchDurationAll 等常量。仅搜索视频时有效
+	Tids       int        `json:"tids,omitempty" request:"query,omitempty"`        // 视频分区筛选。0为全部分区，其余为分区tid。仅搜索视频时有效
+	CategoryId int        `json:"category_id,omitempty" request:"query,omitempty"` // 专栏分区筛选。0为全部分区。仅搜索专栏时有效
+	Page       int        `json:"page,omitempty" request:"query,omitempty"`        // 页码。默认为1，最大为50
+	PageSize   int        `json:"page_size,omitempty" request:"query,omitempty"`   // 每页条数。默认为20，最大为50
+}
+
+// SearchTypeResult 分类搜索结果，Result 中的文本已经去掉了高亮标签并解码了HTML转义字符
+type SearchTypeResult[T any] struct {
+	Seid           string             `json:"seid"`            // 搜索id
+	Page           int                `json:"page"`            // 当前页码
+	PageSize       int                `json:"pagesize"`        // 每页条数
+	NumResults     int                `json:"numResults"`      // 总条数，最大值为1000
+	NumPages       int                `json:"numPages"`        // 分页数，最大值为50
+	SuggestKeyword string             `json:"suggest_keyword"` // 作用尚不明确
+	RqtType        string             `json:"rqt_type"`        // 作用尚不明确
+	CostTime       SearchRespCostTime `json:"cost_time"`       // 详细搜索用时
+	EggHit         int                `json:"egg_hit"`         // 作用尚不明确
+	ShowColumn     int                `json:"show_column"`     // 作用尚不明确
+	Result         []T                `json:"result"`          // 结果列表
+}
+
+type SearchVideoResult struct {
+	Type         string   `json:"type"`           // 结果类型。video
+	Id           int      `json:"id"`             // 稿件avid
+	Author       string   `json:"author"`         // UP主昵称
+	Mid          int      `json:"mid"`            // UP主mid
+	Typeid       string   `json:"typeid"`         // 视频分区tid
+	Typename     string   `json:"typename"`       // 视频子分区名
+	Arcurl       string   `json:"arcurl"`         // 视频重定向url
+	Aid          int      `json:"aid"`            // 稿件avid
+	Bvid         string   `json:"bvid"`           // 稿件bvid
+	Title        string   `json:"title"`          // 视频标题
+	Description  string   `json:"description"`    // 视频简介
+	Pic          string   `json:"pic"`            // 视频封面url
+	Play         int      `json:"play"`           // 视频播放量
+	VideoReview  int      `json:"video_review"`   // 视频弹幕量
+	Favorites    int      `json:"favorites"`      // 视频收藏数
+	Tag          string   `json:"tag"`            // 视频TAG。每项TAG间使用,分隔
+	Review       int      `json:"review"`         // 视频评论数
+	Pubdate      int      `json:"pubdate"`        // 视频投稿时间。时间戳
+	Senddate     int      `json:"senddate"`       // 视频发布时间。时间戳
+	Duration     string   `json:"duration"`       // 视频时长。格式为 MM:SS
+	IsPay        int      `json:"is_pay"`         // 作用尚不明确
+	IsUnionVideo int      `json:"is_union_video"` // 是否为合作视频。0：否。1：是
+	RankScore    int      `json:"rank_score"`     // 结果排序量化值
+	Like         int      `json:"like"`           // 视频点赞数
+	Upic         string   `json:"upic"`           // UP主头像url
+	Danmaku      int      `json:"danmaku"`        // 视频弹幕量
+	HitColumns   []string `json:"hit_columns"`    // 关键字匹配类型
+}
+
+func (r *SearchVideoResult) clean() {
+	r.Title = stripSearchHighlight(r.Title)
+	r.Description = stripSearchHighlight(r.Description)
+	r.Tag = stripSearchHighlight(r.Tag)
+	r.Author = stripSearchHighlight(r.Author)
+}
+
+type SearchUserOfficialVerify struct {
+	Type int    `json:"type"` // 认证类型。0：个人认证。1：机构认证。127：无
+	Desc string `json:"desc"` // 认证信息
+}
+
+type SearchUserVideo struct {
+	Aid          int    `json:"aid"`            // 稿件avid
+	Bvid         string `json:"bvid"`           // 稿件bvid
+	Title        string `json:"title"`          // 稿件标题
+	Pubdate      int    `json:"pubdate"`        // 投稿时间。时间戳
+	Arcurl       string `json:"arcurl"`         // 稿件url
+	Pic          string `json:"pic"`            // 稿件封面url
+	Play         string `json:"play"`           // 播放量
+	Dm           int    `json:"dm"`             // 弹幕量
+	Coin         int    `json:"coin"`           // 投币数
+	Fav          int    `json:"fav"`            // 收藏数
+	Desc         string `json:"desc"`           // 稿件简介
+	Duration     string `json:"duration"`       // 稿件时长。格式为 MM:SS
+	IsPay        int    `json:"is_pay"`         // 作用尚不明确
+	IsUnionVideo int    `json:"is_union_video"` // 是否为合作视频
+}
+
+type SearchUserResult struct {
+	Type           string                   `json:"type"`            // 结果类型。bili_user
+	Mid            int                      `json:"mid"`             // 用户mid
+	Uname          string                   `json:"uname"`           // 用户昵称
+	Usign          string                   `json:"usign"`           // 用户签名
+	Fans           int                      `json:"fans"`            // 粉丝数
+	Videos         int                      `json:"videos"`          // 稿件数
+	Upic           string                   `json:"upic"`            // 头像url
+	Level          int                      `json:"level"`           // 当前等级
+	Gender         int                      `json:"gender"`          // 性别。1：男。2：女。3：私密
+	IsUpuser       int                      `json:"is_upuser"`       // 是否为UP主。0：否。1：是
+	IsLive         int                      `json:"is_live"`         // 是否正在直播。0：否。1：是
+	RoomId         int                      `json:"room_id"`         // 直播间id
+	Res            []SearchUserVideo        `json:"res"`             // 用户投稿内容
+	OfficialVerify SearchUserOfficialVerify `json:"official_verify"` // 认证信息
+	HitColumns     []string                 `json:"hit_columns"`     // 关键字匹配类型
+}
+
+func (r *SearchUserResult) clean() {
+	r.Uname = stripSearchHighlight(r.Uname)
+	r.Usign = stripSearchHighlight(r.Usign)
+	for i := range r.Res {
+		r.Res[i].Title = stripSearchHighlight(r.Res[i].Title)
+		r.Res[i].Desc = stripSearchHighlight(r.Res[i].Desc)
+	}
+}
+
+type SearchArticleResult struct {
+	Type         string   `json:"type"`          // 结果类型。article
+	Id           int      `json:"id"`            // 专栏cvid
+	Mid          int      `json:"mid"`           // UP主mid
+	Title        string   `json:"title"`         // 文章标题
+	Desc         string   `json:"desc"`          // 文章摘要
+	ImageUrls    []string `json:"image_urls"`    // 文章封面url
+	View         int      `json:"view"`          // 阅读数
+	Like         int      `json:"like"`          // 点赞数
+	Reply        int      `json:"reply"`         // 评论数
+	CategoryId   int      `json:"category_id"`   // 文章子分区tid
+	CategoryName string   `json:"category_name"` // 文章子分区名
+	PubTime      int      `json:"pub_time"`      // 投稿时间。时间戳
+	TemplateId   int      `json:"template_id"`   // 作用尚不明确
+	RankScore    int      `json:"rank_score"`    // 结果排序量化值
+}
+
+func (r *SearchArticleResult) clean() {
+	r.Title = stripSearchHighlight(r.Title)
+	r.Desc = stripSearchHighlight(r.Desc)
+}
+
+type SearchLiveRoomResult struct {
+	Type       string   `json:"type"`        // 结果类型。live_room
+	Uid        int      `json:"uid"`         // 主播mid
+	Roomid     int      `json:"roomid"`      // 直播间id
+	ShortId    int      `json:"short_id"`    // 直播间短号
+	Title      string   `json:"title"`       // 直播间标题
+	Cover      string   `json:"cover"`       // 关键帧截图url
+	UserCover  string   `json:"user_cover"`  // 直播间封面url
+	Uface      string   `json:"uface"`       // 主播头像url
+	Uname      string   `json:"uname"`       // 主播昵称
+	Tags       string   `json:"tags"`        // 直播间标签
+	CateName   string   `json:"cate_name"`   // 子分区名
+	Area       int      `json:"area"`        // 作用尚不明确
+	LiveStatus int      `json:"live_status"` // 直播状态。0：未开播。1：直播中
+	Online     int      `json:"online"`      // 在线人数
+	LiveTime   string   `json:"live_time"`   // 开播时间。格式为 YYYY-MM-DD HH:MM:SS
+	Attentions int      `json:"attentions"`  // 关注数
+	RankScore  int      `json:"rank_score"`  // 结果排序量化值
+	HitColumns []string `json:"hit_columns"` // 关键字匹配类型
+}
+
+func (r *SearchLiveRoomResult) clean() {
+	r.Title = stripSearchHighlight(r.Title)
+	r.Uname = stripSearchHighlight(r.Uname)
+	r.Tags = stripSearchHighlight(r.Tags)
+}
+
+type SearchBangumiScore struct {
+	Score     float64 `json:"score"`      // 评分
+	UserCount int     `json:"user_count"` // 评分人数
+}
+
+type SearchBangumiEp struct {
+	Id         int    `json:"id"`          // 剧集epid
+	Cover      string `json:"cover"`       // 剧集封面url
+	Title      string `json:"title"`       // 剧集短标题
+	Url        string `json:"url"`         // 剧集url
+	IndexTitle string `json:"index_title"` // 剧集序号
+	LongTitle  string `json:"long_title"`  // 剧集标题
+}
+
+type SearchBangumiResult struct {
+	Type           string             `json:"type"`             // 结果类型。media_bangumi 或 media_ft
+	MediaId        int                `json:"media_id"`         // 剧集mdid
+	SeasonId       int                `json:"season_id"`        // 剧集ssid
+	Title          string             `json:"title"`            // 剧集标题
+	OrgTitle       string             `json:"org_title"`        // 剧集原名
+	Cover          string             `json:"cover"`            // 剧集封面url
+	MediaType      int                `json:"media_type"`       // 剧集类型。1：番剧。2：电影。3：纪录片。4：国创。5：电视剧。7：综艺
+	Areas          string             `json:"areas"`            // 地区
+	Styles         string             `json:"styles"`           // 风格
+	Cv             string             `json:"cv"`               // 声优
+	Staff          string             `json:"staff"`            // 制作组
+	GotoUrl        string             `json:"goto_url"`         // 重定向url
+	Desc           string             `json:"desc"`             // 简介
+	Pubtime        int                `json:"pubtime"`          // 开播时间。时间戳
+	IsAvid         bool               `json:"is_avid"`          // 作用尚不明确
+	MediaScore     SearchBangumiScore `json:"media_score"`      // 评分信息
+	SeasonTypeName string             `json:"season_type_name"` // 剧集类型名
+	EpSize         int                `json:"ep_size"`          // 剧集数
+	Url            string             `json:"url"`              // 剧集url
+	IsFollow       int                `json:"is_follow"`        // 是否追番。0：否。1：是。需要登录
+	IndexShow      string             `json:"index_show"`       // 更新进度
+	Eps            []SearchBangumiEp  `json:"eps"`              // 剧集列表
+}
+
+func (r *SearchBangumiResult) clean() {
+	r.Title = stripSearchHighlight(r.Title)
+	r.OrgTitle = stripSearchHighlight(r.OrgTitle)
+	r.Desc = stripSearchHighlight(r.Desc)
+	r.Cv = stripSearchHighlight(r.Cv)
+	r.Staff = stripSearchHighlight(r.Staff)
+}
+
+var searchHighlightRegexp = regexp.MustCompile(`</?em[^>]*>`)
+
+// stripSearchHighlight 去掉搜索结果中的 <em class="keyword"> 高亮标签，并解码HTML转义字符
+func stripSearchHighlight(s string) string {
+	return html.UnescapeString(searchHighlightRegexp.ReplaceAllString(s, ""))
+}
+
+func searchByType[T any, PT interface {
+	*T
+	clean()
+}](c *Client, typ SearchType, param SearchTypeParam) (*SearchTypeResult[T], error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/web-interface/wbi/search/type"
+	)
+	param.SearchType = typ
+	result, err := execute[*SearchTypeResult[T]](c, method, url, param, fillWbiHandler(c.wbi, c.GetCookies()))
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Result {
+		PT(&result.Result[i]).clean()
+	}
+	return result, nil
+}
+
+// SearchVideo 分类搜索视频
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_request.md
+func (c *Client) SearchVideo(param SearchTypeParam) (*SearchTypeResult[SearchVideoResult], error) {
+	return searchByType[SearchVideoResult](c, SearchTypeVideo, param)
+}
+
+// SearchUser 分类搜索用户
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_request.md
+func (c *Client) SearchUser(param SearchTypeParam) (*SearchTypeResult[SearchUserResult], error) {
+	return searchByType[SearchUserResult](c, SearchTypeUser, param)
+}
+
+// SearchArticle 分类搜索专栏
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_request.md
+func (c *Client) SearchArticle(param SearchTypeParam) (*SearchTypeResult[SearchArticleResult], error) {
+	return searchByType[SearchArticleResult](c, SearchTypeArticle, param)
+}
+
+// SearchLiveRoom 分类搜索直播间
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_request.md
+func (c *Client) SearchLiveRoom(param SearchTypeParam) (*SearchTypeResult[SearchLiveRoomResult], error) {
+	return searchByType[SearchLiveRoomResult](c, SearchTypeLiveRoom, param)
+}
+
+// SearchBangumi 分类搜索番剧
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_request.md
+func (c *Client) SearchBangumi(param SearchTypeParam) (*SearchTypeResult[SearchBangumiResult], error) {
+	return searchByType[SearchBangumiResult](c, SearchTypeBangumi, param)
+}
+
+// SearchMovie 分类搜索影视
+//
+// 见 https://github.com/SocialSisterYi/bilibili-API-collect/blob/master/docs/search/search_request.md
+func (c *Client) SearchMovie(param SearchTypeParam) (*SearchTypeResult[SearchBangumiResult], error) {
+	return searchByType[SearchBangumiResult](c, SearchTypeMovie, param)
+}
+
+// SearchAll 从 param.Page 开始依次翻页，逐条返回所有搜索结果，最多翻到第50页。出错时返回错误并停止
+//
+// 例如：
+//
+//	for video, err := range bilibili.SearchAll(client.SearchVideo, bilibili.SearchTypeParam{Keyword: "原神"}) {
+//		...
+//	}
+func SearchAll[T any](search func(SearchTypeParam) (*SearchTypeResult[T], error), param SearchTypeParam) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := max(param.Page, 1); page <= searchMaxPages; page++ {
+			param.Page = page
+			result, err := search(param)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range result.Result {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(result.Result) == 0 || page >= result.NumPages {
+				return
+			}
+		}
+	}
+}
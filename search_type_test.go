@@ -0,0 +1,15 @@
+package bilibili
+
+import "testing"
+
+func TestStripSearchHighlight(t *testing.T) {
+	for s, expected := range map[string]string{
+		`<em class="keyword">原神</em>攻略`:                         "原神攻略",
+		`Tom &amp; <em class="keyword">Jerry</em> &#39;01&#39;`: "Tom & Jerry '01'",
+		`没有高亮`: "没有高亮",
+	} {
+		if actual := stripSearchHighlight(s); actual != expected {
+			t.Fatalf("stripSearchHighlight(%q) = %q, expected %q", s, actual, expected)
+		}
+	}
+}
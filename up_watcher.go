@@ -0,0 +1,354 @@
+package bilibili
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UpWatchKind 需要关注的UP主动态种类，可以按位组合
+type UpWatchKind int
+
+const (
+	UpWatchVideo   UpWatchKind = 1 << iota // 投稿视频
+	UpWatchDynamic                         // 动态
+	UpWatchLive                            // 直播状态和直播间标题
+
+	UpWatchAll = UpWatchVideo | UpWatchDynamic | UpWatchLive // 全部
+)
+
+// UpSubscription 对一个UP主的订阅
+type UpSubscription struct {
+	Mid      int           // UP主mid
+	RoomId   int           // 直播间号。为0时会在第一次检查直播状态时通过 GetUserSpaceDetail 获取
+	Kind     UpWatchKind   // 需要关注的种类。为0时关注全部
+	Interval time.Duration // 检查间隔。为0时使用 UpWatcher 的默认间隔
+}
+
+// UpEvent UP主事件，具体类型为 *UpNewVideoEvent 、 *UpNewDynamicEvent 、 *UpLiveStartedEvent 、
+// *UpLiveEndedEvent 、 *UpTitleChangedEvent
+type UpEvent interface {
+	UpMid() int
+}
+
+// UpNewVideoEvent UP主投稿了新视频
+type UpNewVideoEvent struct {
+	Mid   int        // UP主mid
+	Video *UserVideo // 新视频
+}
+
+func (e *UpNewVideoEvent) UpMid() int { return e.Mid }
+
+// UpNewDynamicEvent UP主发布了新动态
+type UpNewDynamicEvent struct {
+	Mid     int      // UP主mid
+	Dynamic *Dynamic // 新动态
+}
+
+func (e *UpNewDynamicEvent) UpMid() int { return e.Mid }
+
+// UpLiveStartedEvent UP主开播了
+type UpLiveStartedEvent struct {
+	Mid  int           // UP主mid
+	Room *LiveRoomInfo // 直播间信息
+}
+
+func (e *UpLiveStartedEvent) UpMid() int { return e.Mid }
+
+// UpLiveEndedEvent UP主下播了
+type UpLiveEndedEvent struct {
+	Mid  int           // UP主mid
+	Room *LiveRoomInfo // 直播间信息
+}
+
+func (e *UpLiveEndedEvent) UpMid() int { return e.Mid }
+
+// UpTitleChangedEvent UP主修改了直播间标题
+type UpTitleChangedEvent struct {
+	Mid      int           // UP主mid
+	OldTitle string        // 原标题
+	Room     *LiveRoomInfo // 直播间信息，其中的 Title 为新标题
+}
+
+func (e *UpTitleChangedEvent) UpMid() int { return e.Mid }
+
+// UpWatchState 一个UP主的已读状态，保存在 Storage 中，键为 up_watcher:{mid}
+type UpWatchState struct {
+	VideoBvids    []string `json:"video_bvids"`    // 最近一次获取到的视频bvid
+	VideoTime     int      `json:"video_time"`     // 已知的最新视频的投稿时间。时间戳
+	DynamicId     string   `json:"dynamic_id"`     // 已知的最新动态id
+	RoomId        int      `json:"room_id"`        // 直播间号
+	LiveStatus    int      `json:"live_status"`    // 直播状态。0：未开播。1：直播中。2：轮播中
+	LiveTitle     string   `json:"live_title"`     // 直播间标题
+	VideoInited   bool     `json:"video_inited"`   // 是否已经记录过视频
+	DynamicInited bool     `json:"dynamic_inited"` // 是否已经记录过动态
+	LiveInited    bool     `json:"live_inited"`    // 是否已经记录过直播状态
+	NoLiveRoom    bool     `json:"no_live_room"`   // UP主没有直播间。为 true 时不再检查直播状态，重新 Subscribe 时会被重置
+}
+
+type upWatchTarget struct {
+	sub  UpSubscription
+	next time.Time
+}
+
+// UpWatcher UP主监视器。按照每个订阅的检查间隔（加上随机抖动）轮询投稿视频、动态和直播间，
+// 与 Storage 中保存的已读状态对比后产生事件。第一次检查某个UP主时只记录状态，不会产生事件。
+// 状态会在事件发出之前保存，因此重启后不会重复通知
+type UpWatcher struct {
+	client   *Client
+	storage  Storage
+	interval time.Duration
+	jitter   time.Duration
+	onError  func(mid int, err error)
+
+	mu      sync.Mutex
+	targets map[int]*upWatchTarget
+}
+
+// NewUpWatcher 返回一个UP主监视器，默认检查间隔为5分钟，随机抖动为30秒，状态保存在内存中
+func (c *Client) NewUpWatcher() *UpWatcher {
+	return &UpWatcher{
+		client:   c,
+		storage:  &MemoryStorage{data: make(map[string]any)},
+		interval: 5 * time.Minute,
+		jitter:   30 * time.Second,
+		targets:  make(map[int]*upWatchTarget),
+	}
+}
+
+// WithStorage 设置保存已读状态的存储，需要在重启后不重复通知时请使用持久化的存储
+func (w *UpWatcher) WithStorage(storage Storage) *UpWatcher {
+	w.storage = storage
+	return w
+}
+
+// WithInterval 设置默认的检查间隔
+func (w *UpWatcher) WithInterval(interval time.Duration) *UpWatcher {
+	w.interval = interval
+	return w
+}
+
+// WithJitter 设置每次检查间隔额外增加的最大随机抖动，避免请求过于集中
+func (w *UpWatcher) WithJitter(jitter time.Duration) *UpWatcher {
+	w.jitter = jitter
+	return w
+}
+
+// WithErrorHandler 设置检查出错时的回调。出错不会中断监视，默认忽略错误
+func (w *UpWatcher) WithErrorHandler(onError func(mid int, err error)) *UpWatcher {
+	w.onError = onError
+	return w
+}
+
+// Subscribe 添加或者更新一个订阅。新的订阅会在一个检查间隔内的随机时间第一次检查，以免大量订阅同时发出请求而触发风控。
+// 之前记录的“没有直播间”会被重置，以便重新检查直播状态
+func (w *UpWatcher) Subscribe(sub UpSubscription) {
+	if sub.Kind == 0 {
+		sub.Kind = UpWatchAll
+	}
+	key := "up_watcher:" + strconv.Itoa(sub.Mid)
+	if state := loadStorageState[UpWatchState](w.storage, key); state.NoLiveRoom {
+		state.NoLiveRoom = false
+		w.storage.Set(key, state)
+	}
+	target := &upWatchTarget{sub: sub}
+	if interval := w.checkInterval(sub); interval > 0 {
+		target.next = time.Now().Add(rand.N(interval))
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.targets[sub.Mid] = target
+}
+
+// Unsubscribe 取消一个订阅，已保存的状态不会被删除
+func (w *UpWatcher) Unsubscribe(mid int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.targets, mid)
+}
+
+// Run 持续监视所有订阅，产生的事件会被串行传给 handler ，直到 ctx 被取消
+func (w *UpWatcher) Run(ctx context.Context, handler func(UpEvent)) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		for _, sub := range w.due(time.Now()) {
+			if ctx.Err() != nil {
+				break
+			}
+			events, err := w.Check(sub)
+			if err != nil && w.onError != nil {
+				w.onError(sub.Mid, err)
+			}
+			for _, event := range events {
+				handler(event)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// due 返回需要检查的订阅，并安排它们的下一次检查时间
+func (w *UpWatcher) due(now time.Time) []UpSubscription {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var subs []UpSubscription
+	for _, target := range w.targets {
+		if now.Before(target.next) {
+			continue
+		}
+		interval := target.sub.Interval
+		if interval <= 0 {
+			interval = w.interval
+		}
+		if w.jitter > 0 {
+			interval += rand.N(w.jitter)
+		}
+		target.next = now.Add(interval)
+		subs = append(subs, target.sub)
+	}
+	return subs
+}
+
+// checkInterval 返回订阅的最大检查间隔，包括随机抖动
+func (w *UpWatcher) checkInterval(sub UpSubscription) time.Duration {
+	interval := sub.Interval
+	if interval <= 0 {
+		interval = w.interval
+	}
+	return interval + max(w.jitter, 0)
+}
+
+// Check 立即检查一个UP主，返回产生的事件。即使出错，已经检查成功的部分产生的事件也会被返回
+func (w *UpWatcher) Check(sub UpSubscription) ([]UpEvent, error) {
+	if sub.Kind == 0 {
+		sub.Kind = UpWatchAll
+	}
+	key := "up_watcher:" + strconv.Itoa(sub.Mid)
+	state := loadStorageState[UpWatchState](w.storage, key)
+	var (
+		events []UpEvent
+		errs   []error
+	)
+	if sub.Kind&UpWatchVideo != 0 {
+		newEvents, err := w.checkVideos(sub, state)
+		events = append(events, newEvents...)
+		errs = append(errs, err)
+	}
+	if sub.Kind&UpWatchDynamic != 0 {
+		newEvents, err := w.checkDynamics(sub, state)
+		events = append(events, newEvents...)
+		errs = append(errs, err)
+	}
+	if sub.Kind&UpWatchLive != 0 {
+		newEvents, err := w.checkLive(sub, state)
+		events = append(events, newEvents...)
+		errs = append(errs, err)
+	}
+	w.storage.Set(key, state)
+	for _, err := range errs {
+		if err != nil {
+			return events, err
+		}
+	}
+	return events, nil
+}
+
+func (w *UpWatcher) checkVideos(sub UpSubscription, state *UpWatchState) ([]UpEvent, error) {
+	videos, err := w.client.GetUserVideos(GetUserVideosParam{Mid: sub.Mid, Ps: 10})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(state.VideoBvids))
+	for _, bvid := range state.VideoBvids {
+		seen[bvid] = struct{}{}
+	}
+	var events []UpEvent
+	bvids := make([]string, 0, len(videos.List.Vlist))
+	videoTime := state.VideoTime
+	for i := range videos.List.Vlist {
+		video := &videos.List.Vlist[i]
+		bvids = append(bvids, video.Bvid)
+		videoTime = max(videoTime, video.Created)
+		if _, ok := seen[video.Bvid]; ok || !state.VideoInited || video.Created < state.VideoTime {
+			continue
+		}
+		events = append(events, &UpNewVideoEvent{Mid: sub.Mid, Video: video})
+	}
+	state.VideoBvids, state.VideoTime, state.VideoInited = bvids, videoTime, true
+	return events, nil
+}
+
+func (w *UpWatcher) checkDynamics(sub UpSubscription, state *UpWatchState) ([]UpEvent, error) {
+	info, err := w.client.GetUserSpaceDynamic(GetUserSpaceDynamicParam{HostMid: strconv.Itoa(sub.Mid), TimezoneOffset: -480, Features: "itemOpusStyle"})
+	if err != nil {
+		return nil, err
+	}
+	var events []UpEvent
+	dynamicId := state.DynamicId
+	for _, d := range info.Dynamics {
+		if d == nil {
+			continue
+		}
+		// 置顶动态可能是很早以前的动态，只按动态id判断是否为新动态
+		if !dynamicIdGreater(d.Id(), state.DynamicId) {
+			continue
+		}
+		if dynamicIdGreater(d.Id(), dynamicId) {
+			dynamicId = d.Id()
+		}
+		if state.DynamicInited {
+			events = append(events, &UpNewDynamicEvent{Mid: sub.Mid, Dynamic: d})
+		}
+	}
+	state.DynamicId, state.DynamicInited = dynamicId, true
+	return events, nil
+}
+
+func (w *UpWatcher) checkLive(sub UpSubscription, state *UpWatchState) ([]UpEvent, error) {
+	roomId := sub.RoomId
+	if roomId == 0 {
+		roomId = state.RoomId
+	}
+	if roomId == 0 {
+		if state.NoLiveRoom {
+			return nil, nil
+		}
+		detail, err := w.client.GetUserSpaceDetail(GetUserSpaceDetailParam{Mid: sub.Mid})
+		if err != nil {
+			return nil, err
+		}
+		if detail.LiveRoom.Roomstatus == 0 {
+			// 只报告一次，之后不再检查
+			state.NoLiveRoom = true
+			return nil, errors.Errorf("用户没有直播间: %d", sub.Mid)
+		}
+		roomId = detail.LiveRoom.Roomid
+	}
+	state.RoomId = roomId
+	room, err := w.client.GetLiveRoomInfo(GetLiveRoomInfoParam{RoomId: roomId})
+	if err != nil {
+		return nil, err
+	}
+	var events []UpEvent
+	if state.LiveInited {
+		if room.LiveStatus == 1 && state.LiveStatus != 1 {
+			events = append(events, &UpLiveStartedEvent{Mid: sub.Mid, Room: room})
+		} else if room.LiveStatus != 1 && state.LiveStatus == 1 {
+			events = append(events, &UpLiveEndedEvent{Mid: sub.Mid, Room: room})
+		}
+		if room.Title != state.LiveTitle {
+			events = append(events, &UpTitleChangedEvent{Mid: sub.Mid, OldTitle: state.LiveTitle, Room: room})
+		}
+	}
+	state.LiveStatus, state.LiveTitle, state.LiveInited = room.LiveStatus, room.Title, true
+	return events, nil
+}
@@ -0,0 +1,130 @@
+package bilibili
+
+import (
+	"encoding/json"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jsonStorage 模拟持久化的 Storage ，值以JSON保存，读取时返回 map[string]any
+type jsonStorage struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (s *jsonStorage) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buf, _ := json.Marshal(value)
+	s.data[key] = buf
+}
+
+func (s *jsonStorage) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buf, ok := s.data[key]
+	if !ok {
+		return nil, false
+	}
+	var v map[string]any
+	_ = json.Unmarshal(buf, &v)
+	return v, true
+}
+
+func TestUpWatcherRestart(t *testing.T) {
+	var (
+		videos     []map[string]any
+		liveStatus int
+		detailHits int
+	)
+	c := newStubClient(t, map[string]stubHandler{
+		"/x/space/wbi/arc/search": func(url.Values) (any, error) {
+			return map[string]any{"list": map[string]any{"vlist": videos}}, nil
+		},
+		"/x/space/wbi/acc/info": func(params url.Values) (any, error) {
+			detailHits++
+			if params.Get("mid") == "2" {
+				return map[string]any{"live_room": map[string]any{"roomStatus": 0}}, nil
+			}
+			return map[string]any{"live_room": map[string]any{"roomStatus": 1, "roomid": 100}}, nil
+		},
+		"/room/v1/Room/get_info": func(url.Values) (any, error) {
+			return map[string]any{"room_id": 100, "live_status": liveStatus, "title": "标题"}, nil
+		},
+	})
+	storage := &jsonStorage{data: make(map[string][]byte)}
+	sub := UpSubscription{Mid: 1, Kind: UpWatchVideo | UpWatchLive}
+	check := func() []UpEvent {
+		// 每次都使用新的 UpWatcher ，模拟程序重启
+		events, err := c.NewUpWatcher().WithStorage(storage).Check(sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+
+	videos = []map[string]any{{"bvid": "BV1", "created": 100}}
+	if events := check(); len(events) != 0 {
+		t.Fatalf("first check should not emit events: %v", events)
+	}
+	videos = []map[string]any{{"bvid": "BV2", "created": 200}, {"bvid": "BV1", "created": 100}}
+	liveStatus = 1
+	events := check()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %v", events)
+	}
+	if e, ok := events[0].(*UpNewVideoEvent); !ok || e.Video.Bvid != "BV2" {
+		t.Fatalf("unexpected video event: %v", events[0])
+	}
+	if _, ok := events[1].(*UpLiveStartedEvent); !ok {
+		t.Fatalf("unexpected live event: %v", events[1])
+	}
+	if events = check(); len(events) != 0 {
+		t.Fatalf("events should not be emitted again after restart: %v", events)
+	}
+	if detailHits != 1 {
+		t.Fatalf("room id should be cached, got %d lookups", detailHits)
+	}
+
+	// 没有直播间的UP主只查询一次，重新订阅后再查询
+	watcher := c.NewUpWatcher().WithStorage(storage)
+	noRoom := UpSubscription{Mid: 2, Kind: UpWatchLive}
+	if _, err := watcher.Check(noRoom); err == nil {
+		t.Fatal("expected error for user without live room")
+	}
+	for range 2 {
+		if _, err := watcher.Check(noRoom); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if detailHits != 2 {
+		t.Fatalf("expected no more lookups, got %d", detailHits)
+	}
+	watcher.Subscribe(noRoom)
+	if _, err := watcher.Check(noRoom); err == nil || detailHits != 3 {
+		t.Fatalf("expected lookup after resubscribe, got %v, %d", err, detailHits)
+	}
+}
+
+func TestUpWatcherDue(t *testing.T) {
+	watcher := (&Client{}).NewUpWatcher().WithInterval(time.Minute).WithJitter(10 * time.Second)
+	for mid := 1; mid <= 100; mid++ {
+		watcher.Subscribe(UpSubscription{Mid: mid})
+	}
+	// 新的订阅分散在一个检查间隔内，不会同时检查
+	now := time.Now()
+	if subs := watcher.due(now); len(subs) > 5 {
+		t.Fatalf("too many subscriptions due at once: %d", len(subs))
+	}
+	first := len(watcher.due(now.Add(35 * time.Second)))
+	if first == 0 || first == 100 {
+		t.Fatalf("subscriptions are not spread: %d", first)
+	}
+	// 超过检查间隔和最大抖动之后全部检查过一次
+	rest := len(watcher.due(now.Add(70 * time.Second)))
+	if first+rest < 100 {
+		t.Fatalf("expected all subscriptions checked, got %d", first+rest)
+	}
+}
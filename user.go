@@ -749,3 +749,19 @@ func (c *Client) GetRelationTags() ([]RelationTag, error) {
 	)
 	return execute[[]RelationTag](c, method, url, nil)
 }
+
+type GetRelationTagUsersParam struct {
+	Tagid     int    `json:"tagid"`                                          // 分组 id。-10：特别关注。0：默认分组
+	OrderType string `json:"order_type,omitempty" request:"query,omitempty"` // 排序方式。按照关注顺序排列：留空。按照最常访问排列：attention
+	Ps        int    `json:"ps,omitempty" request:"query,omitempty"`         // 每页项数。默认为 50
+	Pn        int    `json:"pn,omitempty" request:"query,omitempty"`         // 页码。默认为 1
+}
+
+// GetRelationTagUsers 查询关注分组明细（需要登录）
+func (c *Client) GetRelationTagUsers(param GetRelationTagUsersParam) ([]RelationUser, error) {
+	const (
+		method = resty.MethodGet
+		url    = "https://api.bilibili.com/x/relation/tag"
+	)
+	return execute[[]RelationUser](c, method, url, param)
+}
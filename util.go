@@ -1,6 +1,7 @@
 package bilibili
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
@@ -9,7 +10,9 @@ import (
 	"net/url"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -39,6 +42,16 @@ func fillParam(key, value string) paramHandler {
 	}
 }
 
+// fillParams 设置重复的query参数，例如 uids[]=1&uids[]=2
+func fillParams(key string, values []int) paramHandler {
+	return func(r *resty.Request) error {
+		for _, value := range values {
+			r.QueryParam.Add(key, strconv.Itoa(value))
+		}
+		return nil
+	}
+}
+
 func fillWbiHandler(wbi *WBI, cookies []*http.Cookie) func(*resty.Request) error {
 	return func(r *resty.Request) error {
 		newQuery, err := wbi.SignQuery(r.QueryParam, time.Now())
@@ -77,10 +90,20 @@ func execute[Out any](c *Client, method, url string, in any, handlers ...paramHa
 		return out, errors.WithStack(err)
 	}
 	if cr.Code != 0 {
-		return out, errors.WithStack(Error{Code: cr.Code, Message: cr.Message})
+		message := cr.Message
+		if len(message) == 0 {
+			// 音频相关的接口把错误信息放在 msg 中
+			message = cr.Msg
+		}
+		return out, errors.WithStack(Error{Code: cr.Code, Message: message})
+	}
+	raw := cr.Data
+	if len(raw) == 0 {
+		// PGC相关的接口把数据放在 result 中
+		raw = cr.Result
 	}
 	var data Out
-	if err = json.Unmarshal(cr.Data, &data); err != nil {
+	if err = json.Unmarshal(raw, &data); err != nil {
 		return out, errors.WithStack(err)
 	}
 	return data, errors.WithStack(err)
@@ -89,7 +112,9 @@ func execute[Out any](c *Client, method, url string, in any, handlers ...paramHa
 type commonResp struct {
 	Code    int             `json:"code"`
 	Message string          `json:"message"`
+	Msg     string          `json:"msg"`
 	Data    json.RawMessage `json:"data"`
+	Result  json.RawMessage `json:"result"`
 }
 
 func withParams(r *resty.Request, in any) error {
@@ -170,8 +195,14 @@ func withParams(r *resty.Request, in any) error {
 			_, ok1 := tagMap["json"]
 			_, ok2 := tagMap["form-data"]
 			if !ok1 && !ok2 {
-				// 对query类型的字段进行特殊处理
-				if fieldType.Type.Kind() == reflect.Slice {
+				// 对query类型的字段进行特殊处理，元素为结构体的切片序列化为JSON，其它切片用逗号拼接
+				if fieldType.Type.Kind() == reflect.Slice && fieldType.Type.Elem().Kind() == reflect.Struct {
+					buf, err := json.Marshal(realVal)
+					if err != nil {
+						return errors.WithStack(err)
+					}
+					realVal = string(buf)
+				} else if fieldType.Type.Kind() == reflect.Slice {
 					strSlice := make([]string, 0, 4)
 					for i := range fieldValue.Len() {
 						strSlice = append(strSlice, cast.ToString(fieldValue.Index(i).Interface()))
@@ -265,3 +296,62 @@ func calculateAppSign(params map[string]string, appSecret string) string {
 	hash := md5.Sum([]byte(signStr))
 	return hex.EncodeToString(hash[:])
 }
+
+// pacer 限制请求的频率，保证相邻两次请求之间至少间隔 interval ，可以在多个协程中同时使用
+type pacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait 等待到可以发出下一次请求为止，ctx 被取消时返回错误
+func (p *pacer) wait(ctx context.Context) error {
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+	if d := at.Sub(now); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return errors.WithStack(ctx.Err())
+}
+
+// decodeIntKeyMap 解析以id为键的对象。结果为空时，B站返回的是空数组而不是空对象
+func decodeIntKeyMap[T any](data json.RawMessage) (map[int]T, error) {
+	var result map[int]T
+	if len(data) == 0 || data[0] != '{' {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// parseBackup 解析JSON格式的备份文件，版本号必须在1到 maxVersion 之间。name 为错误信息中的备份名称
+func parseBackup[T any](data []byte, maxVersion int, name string) (*T, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if header.Version <= 0 || header.Version > maxVersion {
+		return nil, errors.Errorf("不支持的%s备份版本: %d", name, header.Version)
+	}
+	backup := new(T)
+	if err := json.Unmarshal(data, backup); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return backup, nil
+}
@@ -219,12 +219,14 @@ func TestFormData(t *testing.T) {
 func TestWithParamsSlice(t *testing.T) {
 	type Test struct {
 		Ids  []int
-		IdsA []string `request:"query"`
+		IdsA []string     `request:"query"`
+		Ctrl []FormatCtrl `json:"ctrl"`
 	}
 
 	params := Test{
 		Ids:  []int{1, 2, 3},
 		IdsA: []string{"1", "2", "3"},
+		Ctrl: []FormatCtrl{{Location: 0, Type: 1, Length: 4, Data: "123"}},
 	}
 
 	r := resty.New().R()
@@ -240,9 +242,10 @@ func TestWithParamsSlice(t *testing.T) {
 		query[k] = r.QueryParam.Get(k)
 	}
 
-	if len(r.QueryParam) != 2 ||
+	if len(r.QueryParam) != 3 ||
 		r.QueryParam.Get("ids") != "1,2,3" ||
-		r.QueryParam.Get("ids_a") != "1,2,3" {
+		r.QueryParam.Get("ids_a") != "1,2,3" ||
+		r.QueryParam.Get("ctrl") != `[{"location":0,"type":1,"length":4,"data":"123"}]` {
 		t.Fatal("withParams query result not correct ", r.QueryParam)
 	}
 }
@@ -0,0 +1,154 @@
+package bilibili
+
+import (
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+type AddVideoSeriesParam struct {
+	Mid         int    `json:"mid,omitempty" request:"query,omitempty"`         // 当前用户mid。默认从cookie中获取
+	Name        string `json:"name"`                                            // 视频列表标题
+	Keywords    string `json:"keywords,omitempty" request:"query,omitempty"`    // 关键词。用逗号分隔
+	Description string `json:"description,omitempty" request:"query,omitempty"` // 简介
+	Aids        []int  `json:"aids,omitempty" request:"query,omitempty"`        // 初始加入的稿件avid
+}
+
+// AddVideoSeries 在个人空间中新建视频列表并加入稿件，返回视频列表id
+func (c *Client) AddVideoSeries(param AddVideoSeriesParam) (int, error) {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/series/series/createAndAddArchives"
+	)
+	if param.Mid == 0 {
+		param.Mid = cast.ToInt(c.getCookie("DedeUserID"))
+	}
+	return execute[int](c, method, url, param, fillCsrf(c))
+}
+
+type EditVideoSeriesParam struct {
+	Mid         int    `json:"mid,omitempty" request:"query,omitempty"`         // 当前用户mid。默认从cookie中获取
+	SeriesId    int    `json:"series_id"`                                       // 视频列表id
+	Name        string `json:"name"`                                            // 视频列表标题
+	Keywords    string `json:"keywords,omitempty" request:"query,omitempty"`    // 关键词。用逗号分隔
+	Description string `json:"description,omitempty" request:"query,omitempty"` // 简介
+}
+
+// EditVideoSeries 修改视频列表的信息
+func (c *Client) EditVideoSeries(param EditVideoSeriesParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/series/series/update"
+	)
+	if param.Mid == 0 {
+		param.Mid = cast.ToInt(c.getCookie("DedeUserID"))
+	}
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type VideoSeriesParam struct {
+	Mid      int `json:"mid,omitempty" request:"query,omitempty"` // 当前用户mid。默认从cookie中获取
+	SeriesId int `json:"series_id"`                               // 视频列表id
+}
+
+// DeleteVideoSeries 删除视频列表。列表中的稿件不会被删除
+func (c *Client) DeleteVideoSeries(param VideoSeriesParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/series/series/delete"
+	)
+	if param.Mid == 0 {
+		param.Mid = cast.ToInt(c.getCookie("DedeUserID"))
+	}
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+type VideoSeriesArchivesParam struct {
+	Mid      int   `json:"mid,omitempty" request:"query,omitempty"` // 当前用户mid。默认从cookie中获取
+	SeriesId int   `json:"series_id"`                               // 视频列表id
+	Aids     []int `json:"aids"`                                    // 稿件avid
+}
+
+// AddVideoSeriesArchives 把自己的稿件加入视频列表
+func (c *Client) AddVideoSeriesArchives(param VideoSeriesArchivesParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/series/series/addArchives"
+	)
+	if param.Mid == 0 {
+		param.Mid = cast.ToInt(c.getCookie("DedeUserID"))
+	}
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// DeleteVideoSeriesArchives 把稿件移出视频列表
+func (c *Client) DeleteVideoSeriesArchives(param VideoSeriesArchivesParam) error {
+	const (
+		method = resty.MethodPost
+		url    = "https://api.bilibili.com/x/series/series/delArchives"
+	)
+	if param.Mid == 0 {
+		param.Mid = cast.ToInt(c.getCookie("DedeUserID"))
+	}
+	_, err := execute[any](c, method, url, param, fillCsrf(c))
+	return err
+}
+
+// SyncVideoSeries 把视频列表中的稿件同步为 bvids ，会自动计算需要加入和移出的稿件。
+// 视频列表中的稿件总是按发布时间排列，无法自定义顺序，因此不会修改排序，返回值中的 Reordered 总是为 false 。
+// 会先检查所有bvid的格式，再修改视频列表。修改中途出错时返回已经完成的修改以及错误
+func (c *Client) SyncVideoSeries(seriesId int, bvids []string) (*CreativeSyncResult, error) {
+	if err := checkDuplicateBvids(bvids); err != nil {
+		return nil, err
+	}
+	aids := make(map[string]int, len(bvids))
+	for _, bvid := range bvids {
+		if len(bvid) != 12 {
+			return nil, errors.Errorf("bvid 格式错误: %s", bvid)
+		}
+		aids[bvid] = Bv2Av(bvid)
+	}
+	mid := cast.ToInt(c.getCookie("DedeUserID"))
+	if mid == 0 {
+		return nil, errors.New("B站登录过期")
+	}
+	var current []string
+	for pn := 1; ; pn++ {
+		info, err := c.GetVideoSeriesInfo(GetVideoSeriesInfoParam{Mid: mid, SeriesId: seriesId, Pn: pn, Ps: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, archive := range info.Archives {
+			current = append(current, archive.Bvid)
+			aids[archive.Bvid] = archive.Aid
+		}
+		if len(info.Archives) == 0 || len(current) >= info.Page.Total {
+			break
+		}
+	}
+	added, removed, _ := diffCreativeOrder(current, bvids)
+	toAids := func(bvids []string) []int {
+		result := make([]int, 0, len(bvids))
+		for _, bvid := range bvids {
+			result = append(result, aids[bvid])
+		}
+		return result
+	}
+	result := &CreativeSyncResult{}
+	if len(removed) > 0 {
+		if err := c.DeleteVideoSeriesArchives(VideoSeriesArchivesParam{Mid: mid, SeriesId: seriesId, Aids: toAids(removed)}); err != nil {
+			return result, err
+		}
+		result.Removed = removed
+	}
+	if len(added) > 0 {
+		if err := c.AddVideoSeriesArchives(VideoSeriesArchivesParam{Mid: mid, SeriesId: seriesId, Aids: toAids(added)}); err != nil {
+			return result, err
+		}
+		result.Added = added
+	}
+	return result, nil
+}
@@ -0,0 +1,314 @@
+package bilibili
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// VideoStatSample 视频数据的一次采样
+type VideoStatSample struct {
+	Bvid     string `json:"bvid"`     // 稿件bvid
+	Time     int64  `json:"time"`     // 采样时间。时间戳
+	Pubdate  int64  `json:"pubdate"`  // 稿件发布时间。时间戳
+	View     int    `json:"view"`     // 播放数
+	Danmaku  int    `json:"danmaku"`  // 弹幕数
+	Reply    int    `json:"reply"`    // 评论数
+	Favorite int    `json:"favorite"` // 收藏数
+	Coin     int    `json:"coin"`     // 投币数
+	Share    int    `json:"share"`    // 分享数
+	Like     int    `json:"like"`     // 点赞数
+	Online   string `json:"online"`   // 所有终端总计在线人数，例如“10万+”
+}
+
+// OnlineCount 把 Online 解析为数字，例如“10万+”解析为100000，无法解析时返回0
+func (s *VideoStatSample) OnlineCount() int {
+	online := strings.TrimSuffix(strings.TrimSpace(s.Online), "+")
+	multiplier := 1
+	if strings.HasSuffix(online, "万") {
+		online = strings.TrimSuffix(online, "万")
+		multiplier = 10000
+	}
+	return int(cast.ToFloat64(online) * float64(multiplier))
+}
+
+// VideoStatMetrics 根据采样计算的衍生指标
+type VideoStatMetrics struct {
+	ViewsPerHour  float64 // 每小时播放数。有上一次采样时为两次采样之间的速度，否则为发布以来的平均速度
+	LikeViewRatio float64 // 点赞数与播放数之比
+	CoinRate      float64 // 投币数与播放数之比
+	FavoriteRate  float64 // 收藏数与播放数之比
+}
+
+// ComputeVideoStatMetrics 根据本次采样和上一次采样计算衍生指标，prev 可以为 nil
+func ComputeVideoStatMetrics(prev, cur *VideoStatSample) *VideoStatMetrics {
+	metrics := &VideoStatMetrics{}
+	if cur.View > 0 {
+		metrics.LikeViewRatio = float64(cur.Like) / float64(cur.View)
+		metrics.CoinRate = float64(cur.Coin) / float64(cur.View)
+		metrics.FavoriteRate = float64(cur.Favorite) / float64(cur.View)
+	}
+	if prev != nil && cur.Time > prev.Time {
+		metrics.ViewsPerHour = float64(cur.View-prev.View) / (float64(cur.Time-prev.Time) / 3600)
+	} else if cur.Pubdate > 0 && cur.Time > cur.Pubdate {
+		metrics.ViewsPerHour = float64(cur.View) / (float64(cur.Time-cur.Pubdate) / 3600)
+	}
+	return metrics
+}
+
+// VideoStatSink 保存视频数据采样的时间序列存储，需要支持并发调用
+type VideoStatSink interface {
+	Write(sample *VideoStatSample) error
+}
+
+// MemoryVideoStatSink 把采样保存在内存中的 VideoStatSink
+type MemoryVideoStatSink struct {
+	mu      sync.Mutex
+	samples map[string][]VideoStatSample
+}
+
+// NewMemoryVideoStatSink 返回一个把采样保存在内存中的 VideoStatSink
+func NewMemoryVideoStatSink() *MemoryVideoStatSink {
+	return &MemoryVideoStatSink{samples: make(map[string][]VideoStatSample)}
+}
+
+func (s *MemoryVideoStatSink) Write(sample *VideoStatSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples[sample.Bvid] = append(s.samples[sample.Bvid], *sample)
+	return nil
+}
+
+// Samples 返回一个稿件的所有采样，按采样时间从早到晚排列
+func (s *MemoryVideoStatSink) Samples(bvid string) []VideoStatSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]VideoStatSample(nil), s.samples[bvid]...)
+}
+
+type jsonlVideoStatSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONLVideoStatSink 返回一个把采样以JSON Lines格式写入 w 的 VideoStatSink ，每行一条
+func NewJSONLVideoStatSink(w io.Writer) VideoStatSink {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonlVideoStatSink{encoder: encoder}
+}
+
+func (s *jsonlVideoStatSink) Write(sample *VideoStatSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.WithStack(s.encoder.Encode(sample))
+}
+
+var videoStatCSVHeader = []string{"bvid", "time", "pubdate", "view", "danmaku", "reply", "favorite", "coin", "share", "like", "online"}
+
+type csvVideoStatSink struct {
+	mu     sync.Mutex
+	writer *csv.Writer
+	header bool
+}
+
+// NewCSVVideoStatSink 返回一个把采样以CSV格式写入 w 的 VideoStatSink ，每次写入后都会刷新缓冲。
+// header 为 true 时会在第一次写入前写入标题行，追加到之前的文件末尾时应为 false
+func NewCSVVideoStatSink(w io.Writer, header bool) VideoStatSink {
+	return &csvVideoStatSink{writer: csv.NewWriter(w), header: header}
+}
+
+func (s *csvVideoStatSink) Write(sample *VideoStatSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.header {
+		if err := s.writer.Write(videoStatCSVHeader); err != nil {
+			return errors.WithStack(err)
+		}
+		s.header = false
+	}
+	err := s.writer.Write([]string{
+		sample.Bvid, strconv.FormatInt(sample.Time, 10), strconv.FormatInt(sample.Pubdate, 10),
+		strconv.Itoa(sample.View), strconv.Itoa(sample.Danmaku), strconv.Itoa(sample.Reply), strconv.Itoa(sample.Favorite),
+		strconv.Itoa(sample.Coin), strconv.Itoa(sample.Share), strconv.Itoa(sample.Like), sample.Online,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	s.writer.Flush()
+	return errors.WithStack(s.writer.Error())
+}
+
+type videoStatTarget struct {
+	bvid    string
+	cid     int
+	pubdate int64
+	next    time.Time
+	last    *VideoStatSample
+}
+
+// VideoStatTracker 视频数据追踪器。定时通过 GetVideoStatusNumber 和 GetVideoOnlineInfo 采样一组稿件的数据并写入 VideoStatSink 。
+// 稿件发布后的一段时间内使用较短的采样间隔，之后使用较长的采样间隔
+type VideoStatTracker struct {
+	client       *Client
+	sink         VideoStatSink
+	fastInterval time.Duration
+	fastDuration time.Duration
+	slowInterval time.Duration
+	onError      func(bvid string, err error)
+
+	mu      sync.Mutex
+	targets map[string]*videoStatTarget
+}
+
+// NewVideoStatTracker 返回一个视频数据追踪器，采样写入 sink 。默认发布后24小时内每5分钟采样一次，之后每小时采样一次
+func (c *Client) NewVideoStatTracker(sink VideoStatSink) *VideoStatTracker {
+	return &VideoStatTracker{
+		client:       c,
+		sink:         sink,
+		fastInterval: 5 * time.Minute,
+		fastDuration: 24 * time.Hour,
+		slowInterval: time.Hour,
+		targets:      make(map[string]*videoStatTarget),
+	}
+}
+
+// WithSchedule 设置采样间隔：稿件发布后 fastDuration 内每 fastInterval 采样一次，之后每 slowInterval 采样一次
+func (t *VideoStatTracker) WithSchedule(fastInterval, fastDuration, slowInterval time.Duration) *VideoStatTracker {
+	t.fastInterval = fastInterval
+	t.fastDuration = fastDuration
+	t.slowInterval = slowInterval
+	return t
+}
+
+// WithErrorHandler 设置采样出错时的回调。出错不会中断追踪，默认忽略错误
+func (t *VideoStatTracker) WithErrorHandler(onError func(bvid string, err error)) *VideoStatTracker {
+	t.onError = onError
+	return t
+}
+
+// Add 开始追踪一个稿件，会通过 GetVideoInfo 获取稿件的发布时间和cid。
+// 新追踪的稿件的第一次采样会随机分散在一个采样间隔内，以免同时添加的大量稿件在同一轮中一起采样
+func (t *VideoStatTracker) Add(bvid string) error {
+	info, err := t.client.GetVideoInfo(VideoParam{Bvid: bvid})
+	if err != nil {
+		return err
+	}
+	target := &videoStatTarget{bvid: bvid, cid: info.Cid, pubdate: int64(info.Pubdate)}
+	now := time.Now()
+	if interval := t.interval(target, now); interval > 0 {
+		target.next = now.Add(rand.N(interval))
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.targets[bvid] = target
+	return nil
+}
+
+// Remove 停止追踪一个稿件
+func (t *VideoStatTracker) Remove(bvid string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.targets, bvid)
+}
+
+// Run 持续追踪所有稿件，每次采样成功后把采样和衍生指标串行传给 handler ， handler 可以为 nil 。直到 ctx 被取消
+func (t *VideoStatTracker) Run(ctx context.Context, handler func(*VideoStatSample, *VideoStatMetrics)) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		for _, target := range t.due(time.Now()) {
+			if ctx.Err() != nil {
+				break
+			}
+			sample, metrics, err := t.sample(target)
+			if err != nil {
+				if t.onError != nil {
+					t.onError(target.bvid, err)
+				}
+			} else if handler != nil {
+				handler(sample, metrics)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// interval 返回稿件当前的采样间隔
+func (t *VideoStatTracker) interval(target *videoStatTarget, now time.Time) time.Duration {
+	if now.Sub(time.Unix(target.pubdate, 0)) < t.fastDuration {
+		return t.fastInterval
+	}
+	return t.slowInterval
+}
+
+// due 返回需要采样的稿件，并安排它们的下一次采样时间
+func (t *VideoStatTracker) due(now time.Time) []*videoStatTarget {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var targets []*videoStatTarget
+	for _, target := range t.targets {
+		if now.Before(target.next) {
+			continue
+		}
+		target.next = now.Add(t.interval(target, now))
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// Sample 立即采样一个已经追踪的稿件并写入 VideoStatSink
+func (t *VideoStatTracker) Sample(bvid string) (*VideoStatSample, *VideoStatMetrics, error) {
+	t.mu.Lock()
+	target, ok := t.targets[bvid]
+	t.mu.Unlock()
+	if !ok {
+		return nil, nil, errors.Errorf("没有追踪该稿件: %s", bvid)
+	}
+	return t.sample(target)
+}
+
+func (t *VideoStatTracker) sample(target *videoStatTarget) (*VideoStatSample, *VideoStatMetrics, error) {
+	stat, err := t.client.GetVideoStatusNumber(VideoParam{Bvid: target.bvid})
+	if err != nil {
+		return nil, nil, err
+	}
+	online, err := t.client.GetVideoOnlineInfo(VideoCidParam{Bvid: target.bvid, Cid: target.cid})
+	if err != nil {
+		return nil, nil, err
+	}
+	sample := &VideoStatSample{
+		Bvid:     target.bvid,
+		Time:     time.Now().Unix(),
+		Pubdate:  target.pubdate,
+		View:     cast.ToInt(stat.View),
+		Danmaku:  stat.Danmaku,
+		Reply:    stat.Reply,
+		Favorite: stat.Favorite,
+		Coin:     stat.Coin,
+		Share:    stat.Share,
+		Like:     stat.Like,
+		Online:   online.Total,
+	}
+	if err = t.sink.Write(sample); err != nil {
+		return nil, nil, err
+	}
+	t.mu.Lock()
+	metrics := ComputeVideoStatMetrics(target.last, sample)
+	target.last = sample
+	t.mu.Unlock()
+	return sample, metrics, nil
+}
@@ -0,0 +1,151 @@
+package bilibili
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestVideoStatSampleOnlineCount(t *testing.T) {
+	for online, expected := range map[string]int{"10万+": 100000, "1.5万+": 15000, "1000+": 1000, "23": 23, "": 0} {
+		sample := &VideoStatSample{Online: online}
+		if count := sample.OnlineCount(); count != expected {
+			t.Fatalf("%s: expected %d, got %d", online, expected, count)
+		}
+	}
+}
+
+func TestComputeVideoStatMetrics(t *testing.T) {
+	prev := &VideoStatSample{Time: 3600, Pubdate: 0, View: 100}
+	cur := &VideoStatSample{Time: 7200, Pubdate: 0, View: 400, Like: 40, Coin: 20, Favorite: 10}
+	metrics := ComputeVideoStatMetrics(prev, cur)
+	if math.Abs(metrics.ViewsPerHour-300) > 1e-9 || metrics.LikeViewRatio != 0.1 || metrics.CoinRate != 0.05 || metrics.FavoriteRate != 0.025 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+	cur.Pubdate = 3600
+	metrics = ComputeVideoStatMetrics(nil, cur)
+	if math.Abs(metrics.ViewsPerHour-400) > 1e-9 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestCSVVideoStatSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewCSVVideoStatSink(&buf, true)
+	for i := range 2 {
+		if err := sink.Write(&VideoStatSample{Bvid: "BV1xx411c7mD", Time: int64(i), View: 1, Online: "10万+"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != strings.Join(videoStatCSVHeader, ",") || lines[2] != "BV1xx411c7mD,1,0,1,0,0,0,0,0,0,10万+" {
+		t.Fatalf("unexpected csv: %s", buf.String())
+	}
+}
+
+func newVideoStatStubClient(t *testing.T, pubdate int64) *Client {
+	return newStubClient(t, map[string]stubHandler{
+		"/x/web-interface/view": func(params url.Values) (any, error) {
+			return map[string]any{"bvid": params.Get("bvid"), "cid": 1, "pubdate": pubdate}, nil
+		},
+		"/x/web-interface/archive/stat": func(params url.Values) (any, error) {
+			return map[string]any{"bvid": params.Get("bvid"), "view": 100, "like": 10}, nil
+		},
+		"/x/player/online/total": func(url.Values) (any, error) {
+			return map[string]any{"total": "1000+"}, nil
+		},
+	})
+}
+
+func TestVideoStatTrackerDue(t *testing.T) {
+	now := time.Now()
+	// 稿件发布40分钟，20分钟后从较短的采样间隔切换为较长的采样间隔
+	pubdate := now.Add(-40 * time.Minute)
+	tracker := newVideoStatStubClient(t, pubdate.Unix()).NewVideoStatTracker(NewMemoryVideoStatSink()).
+		WithSchedule(5*time.Minute, time.Hour, time.Hour)
+	for i := range 20 {
+		if err := tracker.Add("BV" + strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	due := func(now time.Time) int {
+		return len(tracker.due(now))
+	}
+
+	// 新追踪的稿件分散在一个采样间隔内
+	if n := due(now); n != 0 {
+		t.Fatalf("new targets should not be due immediately: %d", n)
+	}
+	first := now.Add(150 * time.Second)
+	spread := due(first)
+	if spread == 0 || spread == 20 {
+		t.Fatalf("new targets are not spread: %d", spread)
+	}
+	fast := now.Add(5*time.Minute + time.Second)
+	if n := due(fast); spread+n != 20 {
+		t.Fatalf("expected all targets sampled within the first interval, got %d", spread+n)
+	}
+
+	// 发布后1小时内使用较短的采样间隔
+	next := now.Add(10*time.Minute + time.Second)
+	if n := due(next); n != 20 {
+		t.Fatalf("expected all targets due, got %d", n)
+	}
+	if n := due(next.Add(4 * time.Minute)); n != 0 {
+		t.Fatalf("expected no target due within fast interval, got %d", n)
+	}
+	next = next.Add(5 * time.Minute)
+	if n := due(next); n != 20 {
+		t.Fatalf("expected fast sampling, got %d", n)
+	}
+	// 超过1小时后切换为较长的采样间隔
+	next = next.Add(5 * time.Minute)
+	if n := due(next); n != 20 {
+		t.Fatalf("expected fast sampling before switch, got %d", n)
+	}
+	if n := due(next.Add(30 * time.Minute)); n != 0 {
+		t.Fatalf("expected slow sampling, got %d", n)
+	}
+	if n := due(next.Add(time.Hour)); n != 20 {
+		t.Fatalf("expected all targets due after slow interval, got %d", n)
+	}
+}
+
+func TestVideoStatTrackerRun(t *testing.T) {
+	sink := NewMemoryVideoStatSink()
+	tracker := newVideoStatStubClient(t, time.Now().Unix()).NewVideoStatTracker(sink).
+		WithSchedule(10*time.Millisecond, time.Hour, time.Hour)
+	bvids := []string{"BV1", "BV2", "BV3"}
+	for _, bvid := range bvids {
+		if err := tracker.Add(bvid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sampled := make(map[string]int)
+	err := tracker.Run(ctx, func(sample *VideoStatSample, metrics *VideoStatMetrics) {
+		if sample.View != 100 || sample.Online != "1000+" || metrics.LikeViewRatio != 0.1 {
+			t.Errorf("unexpected sample: %+v, %+v", sample, metrics)
+		}
+		sampled[sample.Bvid]++
+		if len(sampled) == len(bvids) {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, bvid := range bvids {
+		if sampled[bvid] != 1 || len(sink.Samples(bvid)) != 1 {
+			t.Fatalf("expected one sample for %s, got %v", bvid, sampled)
+		}
+	}
+}
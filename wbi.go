@@ -3,6 +3,7 @@ package bilibili
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"maps"
 	"net/http"
 	"net/url"
@@ -35,6 +36,10 @@ var (
 	}
 )
 
+// Storage 键值存储。除了 WBI 的缓存以外， UpWatcher 、 PrivateMessageSyncer 等也用它保存各自的状态。
+//
+// 如果自己实现持久化的 Storage ，可以把值序列化为JSON保存，读取时直接返回反序列化得到的 map[string]any 等值即可，
+// 这些状态会被重新转换为对应的结构体
 type Storage interface {
 	Set(key string, value any)
 	Get(key string) (v any, isSet bool)
@@ -64,6 +69,29 @@ func (impl *MemoryStorage) Get(key string) (v any, isSet bool) {
 	return nil, false
 }
 
+// loadStorageState 从 Storage 中读取 key 对应的状态。值可以是 *T 、 T 或者能通过JSON转换为 T 的值，
+// 返回的是 Storage 中保存的指针时，修改它也会修改 Storage 中的值。不存在或者无法转换时返回零值
+func loadStorageState[T any](s Storage, key string) *T {
+	state := new(T)
+	v, ok := s.Get(key)
+	if !ok {
+		return state
+	}
+	switch v := v.(type) {
+	case *T:
+		if v != nil {
+			return v
+		}
+		return state
+	case T:
+		return &v
+	}
+	if buf, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(buf, state)
+	}
+	return state
+}
+
 // WBI 签名实现
 // 如果希望以登录的方式获取则使用 WithCookies or WithRawCookies 设置cookie
 // 如果希望以未登录的方式获取 WithCookies(nil) 设置cookie为 nil 即可, 这是 Default 行为